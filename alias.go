@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+)
+
+var AliasFlagAnnotation = "github.com/streamingfast/cli#alias-of"
+
+// FlagAliasOption customizes the behavior of [FlagAlias].
+type FlagAliasOption interface {
+	Apply(opts *flagAliasOptions)
+}
+
+type flagAliasOptions struct {
+	removedIn string
+}
+
+type flagAliasRemovedInOption string
+
+func (o flagAliasRemovedInOption) Apply(opts *flagAliasOptions) {
+	opts.removedIn = string(o)
+}
+
+// FlagAliasRemovedIn mentions the version in which the deprecated flag will be removed in the
+// deprecation warning.
+func FlagAliasRemovedIn(version string) FlagAliasOption {
+	return flagAliasRemovedInOption(version)
+}
+
+// FlagAlias defines the hidden flag `oldName` on the [cobra.Command] as a deprecated alias of
+// the flag `newName`, helping migrate deployments when a flag is renamed. It must be listed
+// after the option defining `newName`, a flag not found leads to a panic.
+//
+// Using `--<oldName>` sets the value of `--<newName>`. When [ConfigureViper] is used, the
+// environment variable and config key derived from `oldName` are also accepted and resolve
+// into the key of `newName`, so reading `newName` through `sflags` getters (or viper) gives
+// back the value provided via the old name. Values provided via the new name always win.
+//
+// Each usage of the old name logs a deprecation warning.
+func FlagAlias(oldName, newName string, opts ...FlagAliasOption) CommandOption {
+	options := flagAliasOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		flags := cmd.PersistentFlags()
+		target := flags.Lookup(newName)
+		if target == nil {
+			flags = cmd.Flags()
+			target = flags.Lookup(newName)
+		}
+
+		if target == nil {
+			panic(fmt.Errorf("flag %q not defined on command %q, cli.FlagAlias must be listed after the flag definition", newName, cmd.Name()))
+		}
+
+		flags.Var(&aliasValue{target}, oldName, fmt.Sprintf("Deprecated, use --%s instead", newName))
+
+		alias := flags.Lookup(oldName)
+		alias.Hidden = true
+		alias.DefValue = target.DefValue
+		alias.NoOptDefVal = target.NoOptDefVal
+		addAnnotation(alias, AliasFlagAnnotation, newName)
+
+		addPreRunHook(cmd, preRunPhaseResolve, func(cmd *cobra.Command) error {
+			return resolveFlagAlias(cmd, oldName, newName, options)
+		})
+	})
+}
+
+func isAliasFlag(flag *pflag.Flag) bool {
+	_, found := flag.Annotations[AliasFlagAnnotation]
+	return found
+}
+
+// aliasValue forwards everything to the target flag.
+type aliasValue struct {
+	target *pflag.Flag
+}
+
+func (v *aliasValue) String() string { return v.target.Value.String() }
+func (v *aliasValue) Type() string   { return v.target.Value.Type() }
+
+func (v *aliasValue) Set(value string) error {
+	if err := v.target.Value.Set(value); err != nil {
+		return err
+	}
+
+	v.target.Changed = true
+	return nil
+}
+
+func resolveFlagAlias(cmd *cobra.Command, oldName, newName string, options flagAliasOptions) error {
+	alias := cmd.Flags().Lookup(oldName)
+	target := cmd.Flags().Lookup(newName)
+	if alias == nil || target == nil {
+		// Local aliased flag of an ancestor, it's not visible to the executed command
+		return nil
+	}
+
+	// The logger is a no-op unless the application configured logging, the user must see the
+	// warnings so they are written to the command's error output too
+	warn := func(kind, oldValue, newValue string) {
+		message := fmt.Sprintf("%s %s is deprecated, use %s instead", kind, oldValue, newValue)
+		if options.removedIn != "" {
+			message += fmt.Sprintf(" (it will be removed in %s)", options.removedIn)
+		}
+
+		fmt.Fprintln(cmd.ErrOrStderr(), "Warning: "+message)
+		zlog.Warn(message)
+	}
+
+	if alias.Changed {
+		warn("flag", "--"+oldName, "--"+newName)
+		return nil
+	}
+
+	oldKey, found := reboundKey(alias)
+	if !found {
+		return nil
+	}
+
+	newKey, _ := reboundKey(target)
+	v := ViperFor(cmd)
+
+	var source ValueSource
+	var value any
+	if env := envVarName(cmd, oldKey); os.Getenv(env) != "" {
+		source, value = ValueSourceEnv, os.Getenv(env)
+		warn("environment variable", env, envVarName(cmd, newKey))
+	} else if v.InConfig(oldKey) {
+		source, value = ValueSourceConfig, v.Get(oldKey)
+		warn("config key", oldKey, newKey)
+	} else {
+		return nil
+	}
+
+	if targetSource, _ := FlagValueSource(cmd, target); targetSource != ValueSourceDefault {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: deprecated value ignored, --%s was provided (from %s)\n", newName, targetSource)
+		zlog.Warn("deprecated value ignored, the new flag was provided", zap.String("flag", newName), zap.String("source", string(targetSource)))
+		return nil
+	}
+
+	setReboundValue(v, newKey, value)
+	setResolvedSource(target, source, value)
+	return nil
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagAlias(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		env        map[string]string
+		wantValue  string
+		wantSource ValueSource
+		wantStderr string
+	}{
+		{"default", nil, nil, "localhost:9000", ValueSourceDefault, ""},
+		{"old flag", []string{"--listen-addr", ":8080"}, nil, ":8080", ValueSourceFlag,
+			"Warning: flag --listen-addr is deprecated, use --http-listen-addr instead (it will be removed in v2.0.0)\n"},
+		{"new flag", []string{"--http-listen-addr", ":8081"}, nil, ":8081", ValueSourceFlag, ""},
+		{"old env", nil, map[string]string{"ACME_GLOBAL_LISTEN_ADDR": ":8082"}, ":8082", ValueSourceEnv,
+			"Warning: environment variable ACME_GLOBAL_LISTEN_ADDR is deprecated, use ACME_GLOBAL_HTTP_LISTEN_ADDR instead (it will be removed in v2.0.0)\n"},
+		{"new env wins", nil, map[string]string{"ACME_GLOBAL_LISTEN_ADDR": ":8082", "ACME_GLOBAL_HTTP_LISTEN_ADDR": ":8083"}, ":8083", ValueSourceEnv,
+			"Warning: environment variable ACME_GLOBAL_LISTEN_ADDR is deprecated, use ACME_GLOBAL_HTTP_LISTEN_ADDR instead (it will be removed in v2.0.0)\n" +
+				"Warning: deprecated value ignored, --http-listen-addr was provided (from env)\n"},
+		{"new flag wins", []string{"--http-listen-addr", ":8084"}, map[string]string{"ACME_GLOBAL_LISTEN_ADDR": ":8082"}, ":8084", ValueSourceFlag,
+			"Warning: environment variable ACME_GLOBAL_LISTEN_ADDR is deprecated, use ACME_GLOBAL_HTTP_LISTEN_ADDR instead (it will be removed in v2.0.0)\n" +
+				"Warning: deprecated value ignored, --http-listen-addr was provided (from flag)\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var value, dashValue string
+			var source ValueSource
+
+			root := Root("acme", "CLI sample application",
+				Execute(func(cmd *cobra.Command, args []string) error {
+					value = ViperFor(cmd).GetString("global.http-listen-addr")
+					dashValue = ViperFor(cmd).GetString("global-http-listen-addr")
+					source, _ = FlagValueSource(cmd, cmd.Flags().Lookup("http-listen-addr"))
+					return nil
+				}),
+				PersistentFlags(func(flags *pflag.FlagSet) {
+					flags.String("http-listen-addr", "localhost:9000", "HTTP listen address")
+				}),
+				FlagAlias("listen-addr", "http-listen-addr", FlagAliasRemovedIn("v2.0.0")),
+				ConfigureViperInstance(viper.New(), "ACME"),
+			)
+
+			assert.NotContains(t, root.PersistentFlags().FlagUsages(), "--listen-addr")
+
+			var stderr bytes.Buffer
+			root.SetErr(&stderr)
+
+			root.SetArgs(tt.args)
+			require.NoError(t, root.Execute())
+
+			assert.Equal(t, tt.wantStderr, stderr.String())
+			assert.Equal(t, tt.wantValue, value)
+			assert.Equal(t, tt.wantValue, dashValue)
+			assert.Equal(t, tt.wantSource, source)
+		})
+	}
+}
+
+func TestFlagAlias_LocalFlagWithSubCommand(t *testing.T) {
+	var executed bool
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+		Flags(func(flags *pflag.FlagSet) {
+			flags.String("http-listen-addr", "localhost:9000", "HTTP listen address")
+		}),
+		FlagAlias("listen-addr", "http-listen-addr"),
+		Command(func(cmd *cobra.Command, args []string) error {
+			executed = true
+			return nil
+		}, "sub", "Sub command"),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	root.SetArgs([]string{"sub"})
+	require.NoError(t, root.Execute())
+	assert.True(t, executed)
+}
+
+func TestFlagAlias_UndefinedTarget(t *testing.T) {
+	assert.Panics(t, func() {
+		Root("acme", "CLI sample application",
+			Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+			FlagAlias("listen-addr", "http-listen-addr"),
+		)
+	})
+}
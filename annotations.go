@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/spf13/cobra"
 )
@@ -14,7 +15,14 @@ func cliAnnotationKey(in string) string {
 
 var cmdToAnnotations = map[*cobra.Command]map[string]any{}
 
+// cmdToAnnotationsLock guards cmdToAnnotations, multiple command trees can be built
+// and executed concurrently (in tests for example).
+var cmdToAnnotationsLock sync.RWMutex
+
 func setCommandAnnotation(cmd *cobra.Command, key string, value any) {
+	cmdToAnnotationsLock.Lock()
+	defer cmdToAnnotationsLock.Unlock()
+
 	cmdAnnotations := cmdToAnnotations[cmd]
 	if cmdAnnotations == nil {
 		cmdAnnotations = make(map[string]any)
@@ -25,6 +33,9 @@ func setCommandAnnotation(cmd *cobra.Command, key string, value any) {
 }
 
 func getCommandAnnotation(cmd *cobra.Command, key string) (any, bool) {
+	cmdToAnnotationsLock.RLock()
+	defer cmdToAnnotationsLock.RUnlock()
+
 	cmdAnnotations, knownCmd := cmdToAnnotations[cmd]
 	if knownCmd {
 		value, found := cmdAnnotations[cliAnnotationKey(key)]
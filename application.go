@@ -2,9 +2,12 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/streamingfast/shutter"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -19,23 +22,84 @@ import (
 type Application struct {
 	appCtx  context.Context
 	shutter *shutter.Shutter
+	options applicationOptions
 
 	isSignaled *atomic.Bool
+	runCtx     *lazySignalContext
+
+	// lock guards the fields below it
+	lock            sync.Mutex
+	children        []*applicationChild
+	readinessChecks []readinessCheck
+	configCmd       *cobra.Command
+	configHandlers  []func(diff ConfigDiff) error
+
+	// reloadLock serializes the config reloads, see [Application.ReloadConfig]
+	reloadLock sync.Mutex
+}
+
+type ApplicationOption interface {
+	Apply(opts *applicationOptions)
+}
+
+type applicationOptions struct {
+	panicAsError bool
+}
+
+type applicationPanicAsErrorOption bool
+
+func (o applicationPanicAsErrorOption) Apply(opts *applicationOptions) {
+	opts.panicAsError = bool(o)
 }
 
-func NewApplication(ctx context.Context) *Application {
+// WithPanicAsError makes panics of children started with [Application.SuperviseAndStart] shut
+// down the child with a [PanicError] instead of exiting the process right away. The application
+// then terminates with it, so it is returned by [Application.WaitForTermination] and flows like
+// any other error returned by your command's handler.
+func WithPanicAsError() ApplicationOption {
+	return applicationPanicAsErrorOption(true)
+}
+
+func NewApplication(ctx context.Context, opts ...ApplicationOption) *Application {
+	options := applicationOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
 	shutter := shutter.New()
 
+	isSignaled := atomic.NewBool(false)
+	runCtx, isRunCtx := ctx.Value(lazySignalContextKey{}).(*lazySignalContext)
+	if isRunCtx {
+		// Deriving from the context of [Run] would register its signal handler right away, it's
+		// registered by WaitForTermination instead so that a single handler, configured with the
+		// application's unready period delay, is used. A context derived from it by the caller
+		// is kept as is, deriving it already registered the handler.
+		if ctx == runCtx {
+			ctx = runCtx.Context
+		}
+
+		isSignaled = runCtx.signaled
+	}
+
 	appCtx, cancelApp := context.WithCancel(ctx)
 	shutter.OnTerminating(func(_ error) {
 		cancelApp()
 	})
 
-	return &Application{
+	app := &Application{
 		appCtx:     appCtx,
 		shutter:    shutter,
-		isSignaled: atomic.NewBool(false),
+		options:    options,
+		isSignaled: isSignaled,
+		runCtx:     runCtx,
 	}
+
+	shutter.OnTerminating(func(_ error) {
+		app.shutdownChildren()
+	})
+
+	return app
 }
 
 // IsReady returns true if the application is ready to be used. When the Ctrl-C signal is received,
@@ -89,11 +153,39 @@ type RunnableContextError interface {
 //
 // The child termination is always performed before the application fully complete, unless
 // the gracecul shutdown delay has expired.
+//
+// The child is named after its type, suffixed by its occurrence (`*pkg.Server#2`) when children
+// of the same type are supervised. Use [Application.SuperviseNamed] to name it and control
+// how it's shut down and [Application.SuperviseAndRestart] for children that should be restarted
+// instead of terminating the application.
 func (a *Application) Supervise(child Shutter) {
+	a.SuperviseNamed(a.defaultChildName(child), child)
+}
+
+// SuperviseNamed is like [Application.Supervise] but names the child, the name is used in
+// logs and health checks (see [Application.ServeHealth]) and must be unique, supervising two
+// children with the same name panics. The options control how the child is shut down when
+// the application terminates:
+//
+//	app.SuperviseNamed("http", server, cli.WithShutdownStage(0), cli.WithShutdownTimeout(10*time.Second))
+//	app.SuperviseNamed("db", db, cli.WithShutdownStage(1))
+//
+// Children are shut down stage by stage, lowest first, all children of a stage being shut
+// down concurrently. The next stage starts once all children of the current one terminated
+// or reached their shutdown timeout. Above, the HTTP server is stopped before the database.
+func (a *Application) SuperviseNamed(name string, child Shutter, opts ...ChildOption) {
+	a.superviseNamed(name, child, opts)
+}
+
+func (a *Application) superviseNamed(name string, child Shutter, opts []ChildOption) *applicationChild {
+	supervised := a.addChild(name, child, opts)
+
+	// Registered before propagating the termination so that it's seen as terminated when
+	// the application shuts down its children
+	child.OnTerminated(func(_ error) { supervised.markTerminated() })
 	child.OnTerminated(a.shutter.Shutdown)
-	a.shutter.OnTerminating(func(_ error) {
-		child.Shutdown(nil)
-	})
+
+	return supervised
 }
 
 // SuperviseAndStart calls [Supervise] and then starts the child in a goroutine. The received
@@ -103,34 +195,75 @@ func (a *Application) Supervise(child Shutter) {
 // The child is started in a goroutine and tied to the application lifecycle because we also
 // called [Supervise]. Later the call to `WaitForTermination` will wait for the application to
 // terminate which will also terminates and wait for all child.
+//
+// A panic of the child's `Run` is recovered, logged and then the process exits with [ExitCodePanic]
+// running the registered exit handlers, see [WithPanicAsError] to shut down the child instead.
 func (a *Application) SuperviseAndStart(child Shutter) {
-	a.Supervise(child)
+	a.SuperviseNamedAndStart(a.defaultChildName(child), child)
+}
 
+// SuperviseNamedAndStart calls [Application.SuperviseNamed] and then starts the child like
+// [Application.SuperviseAndStart] does.
+//
+// When the child depends on other children (see [WithDependsOn]), it's started only once all
+// of them are ready. The application terminates with an error if one of them terminates or
+// is not ready within the startup timeout (see [WithStartupTimeout]).
+func (a *Application) SuperviseNamedAndStart(name string, child Shutter, opts ...ChildOption) {
+	supervised := a.superviseNamed(name, child, opts)
+	run := a.childRunner(child)
+
+	go func() {
+		if err := a.waitForDependencies(supervised); err != nil {
+			if !errors.Is(err, errApplicationTerminating) {
+				a.shutter.Shutdown(err)
+			}
+
+			return
+		}
+
+		a.start(supervised, child, run)
+	}()
+}
+
+func (a *Application) childRunner(child Shutter) func() error {
 	switch v := child.(type) {
 	case Runnable:
-		go v.Run()
+		return func() error { v.Run(); return nil }
 	case RunnableContext:
-		go v.Run(a.appCtx)
+		return func() error { v.Run(a.appCtx); return nil }
 	case RunnableError:
-		go func() {
-			err := v.Run()
-			if err != nil {
-				child.Shutdown(err)
-			}
-		}()
+		return v.Run
 	case RunnableContextError:
-		go func() {
-			err := v.Run(a.appCtx)
-			if err != nil {
-				child.Shutdown(err)
-			}
-		}()
+		return func() error { return v.Run(a.appCtx) }
+	}
+
+	panic(fmt.Errorf("unsupported child type %T, must implement one of cli.Runnable, cli.RunnableContext, cli.RunnableError or cli.RunnableContextError", child))
+}
 
-	default:
-		panic(fmt.Errorf("unsupported child type %T, must implement one of cli.Runnable, cli.RunnableContext, cli.RunnableError or cli.RunnableContextError", child))
+// start runs the child, shutting it down with the error returned by its run function if any.
+func (a *Application) start(supervised *applicationChild, child Shutter, run func() error) {
+	supervised.started.Store(true)
+
+	if err := a.runChild(supervised.name, run); err != nil {
+		child.Shutdown(err)
 	}
 }
 
+func (a *Application) runChild(name string, run func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			panicErr := newPanicError(recovered, zap.String("child", name))
+			if !a.options.panicAsError {
+				Exit(ExitCodePanic)
+			}
+
+			err = panicErr
+		}
+	}()
+
+	return run()
+}
+
 // WaitForTermination waits for the application to terminate. This first setup the signal handler and
 // then wait for either the signal handler to be notified or the application to be terminating.
 //
@@ -138,21 +271,40 @@ func (a *Application) SuperviseAndStart(child Shutter) {
 // all child to gracefully terminate. If the graceful shutdown delay is reached, we force the termination
 // of the application right now.
 //
-// Doing Ctrl-C 4 times or more will lead to a force quit of the whole process by calling `os.Exit(1)`, this
+// Doing Ctrl-C 4 times or more will lead to a force quit of the whole process by calling `cli.Exit(cli.ExitCodeInterrupted)`, this
 // is performed by the signal handler code and is does **not** respect the graceful shutdown delay in this case.
-func (a *Application) WaitForTermination(logger *zap.Logger, unreadyPeriodDelay, gracefulShutdownDelay time.Duration) error {
+//
+// The signal handler can be further configured through `opts`, see [NewSignalHandler], it is stopped
+// once the application terminated. When the config is watched (see [Application.WatchConfig]), SIGHUP
+// reloads it.
+//
+// When the application was created from the context of a command executed by [Run], or from a
+// context derived from it, the signal handler of that context is used instead so that the command's
+// context and the application agree on the signals received, see [ConfigureSignals]. If the context
+// was already waited on before, which deriving it with [context.WithCancel] or [context.WithTimeout]
+// does, its handler is shared as configured by [ConfigureSignals], ignoring the delay and `opts`.
+func (a *Application) WaitForTermination(logger *zap.Logger, unreadyPeriodDelay, gracefulShutdownDelay time.Duration, opts ...SignalOption) error {
 	// On any exit path, we synchronize the logger one last time
 	defer func() {
 		logger.Sync()
 	}()
 
-	signalHandler, isSignaled, _ := SetupSignalHandler(unreadyPeriodDelay, logger)
-
 	// Wire the signal handler to the application
-	a.isSignaled = isSignaled
+	isSignaled := a.isSignaled
+	signalOpts := []SignalOption{WithUnreadyPeriodDelay(unreadyPeriodDelay), withSignaledFlag(isSignaled)}
+	if a.isConfigWatched() {
+		signalOpts = append(signalOpts, WithReloadHandler(func() {
+			if err := a.ReloadConfig(); err != nil {
+				logger.Warn("config reload failed", zap.Error(err))
+			}
+		}))
+	}
+
+	signalCtx, stopSignals := a.signalContext(logger, append(signalOpts, opts...))
+	defer stopSignals()
 
 	select {
-	case <-signalHandler:
+	case <-signalCtx.Done():
 		go a.shutter.Shutdown(nil)
 		break
 	case <-a.shutter.Terminating():
@@ -164,7 +316,9 @@ func (a *Application) WaitForTermination(logger *zap.Logger, unreadyPeriodDelay,
 	select {
 	case <-a.shutter.Terminated():
 	case <-time.After(gracefulShutdownDelay):
-		logger.Warn("application did not terminate within graceful period of " + gracefulShutdownDelay.String() + ", forcing termination")
+		logger.Warn("application did not terminate within graceful period of "+gracefulShutdownDelay.String()+", forcing termination",
+			zap.Strings("running_children", a.runningChildren()),
+		)
 	}
 
 	if err := a.shutter.Err(); err != nil {
@@ -174,3 +328,18 @@ func (a *Application) WaitForTermination(logger *zap.Logger, unreadyPeriodDelay,
 	logger.Info("run terminated gracefully")
 	return nil
 }
+
+// signalContext returns a context cancelled once the application is signaled, sharing the
+// signal handler of the [Run] context the application was created from, if any.
+func (a *Application) signalContext(logger *zap.Logger, opts []SignalOption) (context.Context, func()) {
+	if a.runCtx == nil {
+		return SignalContext(context.Background(), logger, opts...)
+	}
+
+	if !a.runCtx.start(logger, opts...) {
+		logger.Debug("command context already waited on, sharing its signal handler as configured by ConfigureSignals")
+	}
+
+	// The handler is stopped by [Run] once the command returns
+	return a.runCtx, func() {}
+}
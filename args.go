@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var annotationArgs = "args"
+var annotationArgValues = "arg-values"
+
+// ArgOption customizes a positional argument declared with [Arg].
+type ArgOption interface {
+	Apply(def *argDefinition)
+}
+
+type argOptionFunc func(def *argDefinition)
+
+func (f argOptionFunc) Apply(def *argDefinition) {
+	f(def)
+}
+
+// ArgOptional marks the argument as optional, only trailing arguments can be optional.
+func ArgOptional() ArgOption {
+	return argOptionFunc(func(def *argDefinition) { def.optional = true })
+}
+
+// ArgVariadic makes the argument consume all remaining positional arguments, only the
+// last argument can be variadic. A variadic argument requires at least one value unless
+// it's also marked with [ArgOptional]. Use [MustGetArgs] to retrieve its values.
+func ArgVariadic() ArgOption {
+	return argOptionFunc(func(def *argDefinition) { def.variadic = true })
+}
+
+type argDefinition struct {
+	name     string
+	usage    string
+	optional bool
+	variadic bool
+	parse    func(values []string) (any, error)
+}
+
+func (d *argDefinition) String() string {
+	out := "<" + d.name + ">"
+	if d.variadic {
+		out += "..."
+	}
+
+	if d.optional {
+		out = "[" + out + "]"
+	}
+
+	return out
+}
+
+// Arg declares the next positional argument of the command, its value is converted to `T`
+// which can be any type supported by [FlagsFromStruct].
+//
+// Declared arguments generate the command's usage line (replacing any argument already
+// present in the usage) and its positional argument validator, the conversion of each
+// value being part of the validation. They are also described in the command's help. Combining
+// them with another positional arguments validator like [ExactArgs] panics.
+// Converted values are retrieved in the command's handler with [MustGetArg] and [MustGetArgs].
+//
+//	Command(compareE,
+//		"compare",
+//		"Compare two files",
+//		Arg[string]("input_file", "The file to compare"),
+//		Arg[string]("output_file", "The file to compare against, standard input if not provided", ArgOptional()),
+//	)
+func Arg[T any](name, usage string, opts ...ArgOption) CommandOption {
+	var zero T
+	flagType, found := structFlagTypes[reflect.TypeOf(zero)]
+	if !found {
+		panic(fmt.Errorf("argument <%s> has unsupported type %T", name, zero))
+	}
+
+	parse := func(value string) (T, error) {
+		var out T
+
+		flags := pflag.NewFlagSet(name, pflag.ContinueOnError)
+		defineTypedFlag(flags, flagType, name, &out, "")
+
+		err := flags.Lookup(name).Value.Set(value)
+		return out, err
+	}
+
+	def := &argDefinition{name: name, usage: usage}
+	for _, opt := range opts {
+		opt.Apply(def)
+	}
+
+	def.parse = func(values []string) (any, error) {
+		if !def.variadic {
+			return parse(values[0])
+		}
+
+		out := make([]T, len(values))
+		for i, value := range values {
+			parsed, err := parse(value)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = parsed
+		}
+
+		return out, nil
+	}
+
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		if cmd.Args != nil && !isValidateArgs(cmd.Args) {
+			panic(fmt.Errorf("argument <%s> declared on command %q which already has a positional arguments validator, cli.Arg provides its own", name, cmd.Name()))
+		}
+
+		defs := argDefinitions(cmd)
+		if len(defs) > 0 {
+			last := defs[len(defs)-1]
+			if last.variadic {
+				panic(fmt.Errorf("argument <%s> declared after variadic argument <%s>", name, last.name))
+			}
+
+			if last.optional && !def.optional {
+				panic(fmt.Errorf("required argument <%s> declared after optional argument <%s>", name, last.name))
+			}
+		}
+
+		defs = append(defs, def)
+		setCommandAnnotation(cmd, annotationArgs, defs)
+
+		usage := make([]string, len(defs)+1)
+		usage[0] = cmd.Name()
+		for i, def := range defs {
+			usage[i+1] = def.String()
+		}
+
+		cmd.Use = strings.Join(usage, " ")
+		cmd.Args = validateArgs
+	})
+}
+
+// MustGetArg returns the converted value of argument `name` declared with [Arg], the zero
+// value is returned if the argument is optional and was not provided.
+//
+// It panics if the argument was not declared on the command or if `T` is not its type.
+func MustGetArg[T any](cmd *cobra.Command, name string) T {
+	value := argValue(cmd, name)
+	if value == nil {
+		var zero T
+		return zero
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		panic(fmt.Errorf("argument <%s> is of type %T, not %T", name, value, typed))
+	}
+
+	return typed
+}
+
+// MustGetArgs returns the converted values of the variadic argument `name` declared with
+// [Arg] and [ArgVariadic], `nil` is returned if it was optional and not provided.
+//
+// It panics if the argument was not declared on the command or if `T` is not its type.
+func MustGetArgs[T any](cmd *cobra.Command, name string) []T {
+	return MustGetArg[[]T](cmd, name)
+}
+
+// ArgProvided returns `true` if a value for the argument `name` declared with [Arg] was
+// provided on the command line.
+func ArgProvided(cmd *cobra.Command, name string) bool {
+	return argValue(cmd, name) != nil
+}
+
+func argDefinitions(cmd *cobra.Command) []*argDefinition {
+	if defs, found := getCommandAnnotation(cmd, annotationArgs); found {
+		return defs.([]*argDefinition)
+	}
+
+	return nil
+}
+
+func argValue(cmd *cobra.Command, name string) any {
+	for _, def := range argDefinitions(cmd) {
+		if def.name == name {
+			values, _ := getCommandAnnotation(cmd, annotationArgValues)
+			typed, _ := values.(map[string]any)
+			return typed[name]
+		}
+	}
+
+	panic(fmt.Errorf("argument <%s> not declared on command %q", name, cmd.CommandPath()))
+}
+
+func validateArgs(cmd *cobra.Command, args []string) error {
+	defs := argDefinitions(cmd)
+
+	required := 0
+	for _, def := range defs {
+		if !def.optional {
+			required++
+		}
+	}
+
+	if len(args) < required {
+		return usageErrorf("missing required argument <%s>", defs[len(args)].name)
+	}
+
+	if len(args) > len(defs) && (len(defs) == 0 || !defs[len(defs)-1].variadic) {
+		return usageErrorf("accepts at most %d argument(s), received %d", len(defs), len(args))
+	}
+
+	values := map[string]any{}
+	for i, def := range defs {
+		if i >= len(args) {
+			break
+		}
+
+		input := args[i : i+1]
+		if def.variadic {
+			input = args[i:]
+		}
+
+		value, err := def.parse(input)
+		if err != nil {
+			return usageErrorf("invalid argument <%s>: %w", def.name, err)
+		}
+
+		values[def.name] = value
+	}
+
+	setCommandAnnotation(cmd, annotationArgValues, values)
+	return nil
+}
+
+func isValidateArgs(args cobra.PositionalArgs) bool {
+	return reflect.ValueOf(args).Pointer() == reflect.ValueOf(validateArgs).Pointer()
+}
+
+// describeArgs appends the description of the declared arguments to the help of every
+// command of the tree. It panics if the positional arguments validator installed by [Arg]
+// was replaced afterward, the arguments would silently not be parsed otherwise.
+func describeArgs(root *cobra.Command) {
+	visitAllCommands(root, func(cmd *cobra.Command) {
+		defs := argDefinitions(cmd)
+		if len(defs) == 0 {
+			return
+		}
+
+		if !isValidateArgs(cmd.Args) {
+			panic(fmt.Errorf("positional arguments validator of command %q replaced after its arguments were declared with cli.Arg", cmd.CommandPath()))
+		}
+
+		description := &strings.Builder{}
+		writer := tabwriter.NewWriter(description, 0, 0, 3, ' ', 0)
+		for _, def := range defs {
+			fmt.Fprintf(writer, "  %s\t%s\n", def, def.usage)
+		}
+		writer.Flush()
+
+		long := cmd.Long
+		if long == "" {
+			long = cmd.Short
+		}
+
+		cmd.Long = long + "\n\nArguments:\n" + strings.TrimRight(description.String(), "\n")
+	})
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArg(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantInput    string
+		wantRetries  int
+		wantTimeouts []time.Duration
+		wantError    string
+	}{
+		{"required only", []string{"in.json"}, "in.json", 0, nil, ""},
+		{"optional", []string{"in.json", "3"}, "in.json", 3, nil, ""},
+		{"variadic", []string{"in.json", "3", "1s", "2m"}, "in.json", 3, []time.Duration{time.Second, 2 * time.Minute}, ""},
+		{"missing", nil, "", 0, nil, "missing required argument <input_file>"},
+		{"invalid", []string{"in.json", "three"}, "", 0, nil, `invalid argument <retries>: strconv.ParseInt: parsing "three": invalid syntax`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input string
+			var retries int
+			var timeouts []time.Duration
+			var retriesProvided bool
+
+			root := Root("acme", "CLI sample application",
+				Command(func(cmd *cobra.Command, args []string) error {
+					input = MustGetArg[string](cmd, "input_file")
+					retries = MustGetArg[int](cmd, "retries")
+					retriesProvided = ArgProvided(cmd, "retries")
+					timeouts = MustGetArgs[time.Duration](cmd, "timeouts")
+					return nil
+				},
+					"compare <input_file> <retries>",
+					"Compare files",
+					Arg[string]("input_file", "The file to compare"),
+					Arg[int]("retries", "Number of retries", ArgOptional()),
+					Arg[time.Duration]("timeouts", "Timeout of each retry", ArgOptional(), ArgVariadic()),
+				),
+			)
+
+			root.SetArgs(append([]string{"compare"}, tt.args...))
+			err := root.Execute()
+			if tt.wantError != "" {
+				assert.EqualError(t, err, tt.wantError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantInput, input)
+			assert.Equal(t, tt.wantRetries, retries)
+			assert.Equal(t, len(tt.args) > 1, retriesProvided)
+			assert.Equal(t, tt.wantTimeouts, timeouts)
+		})
+	}
+}
+
+func TestArg_Help(t *testing.T) {
+	root := Root("acme", "CLI sample application",
+		Command(func(cmd *cobra.Command, args []string) error { return nil },
+			"compare",
+			"Compare files",
+			Arg[string]("input_file", "The file to compare"),
+			Arg[[]string]("others", "Other files", ArgVariadic()),
+		),
+	)
+
+	var help bytes.Buffer
+	root.SetOut(&help)
+	root.SetArgs([]string{"compare", "--help"})
+	require.NoError(t, root.Execute())
+
+	assert.Contains(t, help.String(), Dedent(`
+		Compare files
+
+		Arguments:
+		  <input_file>   The file to compare
+		  <others>...    Other files
+
+		Usage:
+		  acme compare <input_file> <others>... [flags]
+	`))
+}
+
+func TestArg_InvalidDeclarations(t *testing.T) {
+	assert.Panics(t, func() {
+		Command(nil, "compare", "Compare files", Arg[struct{}]("input", "Input"))
+	})
+
+	assert.Panics(t, func() {
+		Root("acme", "CLI sample application",
+			Command(nil, "compare", "Compare files", Arg[string]("input", "Input", ArgOptional()), Arg[string]("output", "Output")),
+		)
+	})
+
+	assert.PanicsWithError(t, `positional arguments validator of command "acme compare" replaced after its arguments were declared with cli.Arg`, func() {
+		Root("acme", "CLI sample application",
+			Command(nil, "compare", "Compare files", Arg[string]("input", "Input"), ExactArgs(1)),
+		)
+	})
+
+	assert.PanicsWithError(t, `argument <input> declared on command "compare" which already has a positional arguments validator, cli.Arg provides its own`, func() {
+		Root("acme", "CLI sample application",
+			Command(nil, "compare", "Compare files", ExactArgs(1), Arg[string]("input", "Input")),
+		)
+	})
+}
@@ -9,8 +9,8 @@ import (
 //
 // The message can be "" in which case it should not be printed/logged.
 //
-// If your handler does not exit by itself, a call to `cli.Exit(1)` is performed
-// after the handler has executed.
+// If your handler does not exit by itself, a call to `cli.Exit(code)` is performed
+// after the handler has executed, see [Quit] for how the code is determined.
 //
 // If you exit yourself, you should use `cli.Exit(code)` so that exit handlers
 // are called if any present.
@@ -22,18 +22,33 @@ func Ensure(condition bool, message string, args ...interface{}) {
 	}
 }
 
+// NoError quits if `err` is not nil, exiting with the code of the error, see [ExitCode].
 func NoError(err error, message string, args ...interface{}) {
 	if err != nil {
-		Quit(message+": "+err.Error(), args...)
+		quit(ExitCode(err), message+": "+err.Error(), args...)
 	}
 }
 
+// Quit prints the message and exits. The exit code is the one of the first error
+// found in `args`, see [ExitCode], or 1 if there is none.
 func Quit(message string, args ...interface{}) {
+	code := ExitCodeFailure
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			code = ExitCode(err)
+			break
+		}
+	}
+
+	quit(code, message, args...)
+}
+
+func quit(code int, message string, args ...interface{}) {
 	if OnAssertionFailure != nil {
 		OnAssertionFailure(fmt.Sprintf(message, args...))
 	} else {
 		fmt.Printf(message+"\n", args...)
 	}
 
-	Exit(1)
+	Exit(code)
 }
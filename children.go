@@ -0,0 +1,546 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// ChildOption configures how a child is supervised, see [Application.SuperviseNamed].
+type ChildOption interface {
+	Apply(opts *childOptions)
+}
+
+type childOptions struct {
+	shutdownStage   int
+	shutdownTimeout time.Duration
+
+	restartPolicy     RestartPolicy
+	restartBackoff    time.Duration
+	maxRestartBackoff time.Duration
+	maxRestarts       int
+
+	dependsOn      []string
+	startupTimeout time.Duration
+}
+
+type childShutdownStageOption int
+
+func (o childShutdownStageOption) Apply(opts *childOptions) {
+	opts.shutdownStage = int(o)
+}
+
+// WithShutdownStage sets the stage at which the child is shut down when the application
+// terminates, lower stages are shut down first. The default stage is 0.
+func WithShutdownStage(stage int) ChildOption {
+	return childShutdownStageOption(stage)
+}
+
+type childShutdownTimeoutOption time.Duration
+
+func (o childShutdownTimeoutOption) Apply(opts *childOptions) {
+	opts.shutdownTimeout = time.Duration(o)
+}
+
+// WithShutdownTimeout sets the maximum time the application waits for the child to terminate
+// before moving on to the next shutdown stage, so that a stuck child does not prevent the
+// later stages from being shut down. The default is 30s, a value of 0 or lower means no
+// timeout. The whole shutdown is still bounded by the graceful shutdown delay of
+// [Application.WaitForTermination].
+func WithShutdownTimeout(timeout time.Duration) ChildOption {
+	return childShutdownTimeoutOption(timeout)
+}
+
+// RestartPolicy determines if a child is restarted once terminated, see [Application.SuperviseAndRestart].
+type RestartPolicy int
+
+const (
+	// RestartNever never restarts the child, its termination terminates the application
+	RestartNever RestartPolicy = iota
+	// RestartOnFailure restarts the child when it terminates with an error
+	RestartOnFailure
+	// RestartAlways restarts the child whenever it terminates
+	RestartAlways
+)
+
+func (p RestartPolicy) String() string {
+	switch p {
+	case RestartNever:
+		return "never"
+	case RestartOnFailure:
+		return "on-failure"
+	case RestartAlways:
+		return "always"
+	}
+
+	return fmt.Sprintf("RestartPolicy(%d)", int(p))
+}
+
+func (p RestartPolicy) shouldRestart(err error) bool {
+	return p == RestartAlways || (p == RestartOnFailure && err != nil)
+}
+
+type childRestartPolicyOption RestartPolicy
+
+func (o childRestartPolicyOption) Apply(opts *childOptions) {
+	opts.restartPolicy = RestartPolicy(o)
+}
+
+// WithRestartPolicy sets the restart policy of a child supervised with [Application.SuperviseAndRestart],
+// the default being [RestartOnFailure].
+func WithRestartPolicy(policy RestartPolicy) ChildOption {
+	return childRestartPolicyOption(policy)
+}
+
+type childRestartBackoffOption [2]time.Duration
+
+func (o childRestartBackoffOption) Apply(opts *childOptions) {
+	opts.restartBackoff = o[0]
+	opts.maxRestartBackoff = o[1]
+}
+
+// WithRestartBackoff sets the delay before the first restart of a child, doubled on each
+// subsequent restart up to `max`. The default is 1s up to 1m. The delay is reset once an
+// instance of the child stayed up longer than `max`.
+func WithRestartBackoff(initial, max time.Duration) ChildOption {
+	return childRestartBackoffOption{initial, max}
+}
+
+type childMaxRestartsOption int
+
+func (o childMaxRestartsOption) Apply(opts *childOptions) {
+	opts.maxRestarts = int(o)
+}
+
+// WithMaxRestarts sets the maximum number of consecutive restarts of a child, once exhausted
+// the next termination of the child terminates the application. The budget is reset once an
+// instance of the child stayed up longer than the maximum restart backoff (see [WithRestartBackoff]),
+// so it only limits crash loops. A negative value, the default, means no limit.
+func WithMaxRestarts(max int) ChildOption {
+	return childMaxRestartsOption(max)
+}
+
+type childDependsOnOption []string
+
+func (o childDependsOnOption) Apply(opts *childOptions) {
+	opts.dependsOn = append(opts.dependsOn, o...)
+}
+
+// WithDependsOn declares that the child depends on the children named `names`, the child is
+// started only once all of them are ready and it's shut down before them.
+//
+// A child is ready once started and, if it implements [ReadinessChecker], once it reports
+// being ready.
+//
+// The dependencies must be supervised before the child, naming a child not supervised yet
+// panics. This rules out dependency cycles.
+func WithDependsOn(names ...string) ChildOption {
+	return childDependsOnOption(names)
+}
+
+type childStartupTimeoutOption time.Duration
+
+func (o childStartupTimeoutOption) Apply(opts *childOptions) {
+	opts.startupTimeout = time.Duration(o)
+}
+
+// WithStartupTimeout sets the maximum time to wait for the dependencies of the child to be
+// ready, see [WithDependsOn]. The default is 1m, a value of 0 or lower means no timeout.
+func WithStartupTimeout(timeout time.Duration) ChildOption {
+	return childStartupTimeoutOption(timeout)
+}
+
+type applicationChild struct {
+	name    string
+	options childOptions
+	started atomic.Bool
+
+	lock    sync.Mutex
+	shutter Shutter
+
+	terminated     chan struct{}
+	terminatedOnce sync.Once
+}
+
+func (a *Application) addChild(name string, child Shutter, opts []ChildOption) *applicationChild {
+	supervised := &applicationChild{
+		name:       name,
+		shutter:    child,
+		terminated: make(chan struct{}),
+		options: childOptions{
+			shutdownTimeout:   30 * time.Second,
+			restartPolicy:     RestartOnFailure,
+			restartBackoff:    time.Second,
+			maxRestartBackoff: time.Minute,
+			maxRestarts:       -1,
+			startupTimeout:    time.Minute,
+		},
+	}
+
+	for _, opt := range opts {
+		opt.Apply(&supervised.options)
+	}
+
+	supervisedNames := map[string]bool{}
+	for _, existing := range a.childrenSnapshot() {
+		supervisedNames[existing.name] = true
+	}
+
+	if supervisedNames[name] {
+		panic(fmt.Errorf("child %q is already supervised, children names must be unique", name))
+	}
+
+	for _, dependency := range supervised.options.dependsOn {
+		if !supervisedNames[dependency] {
+			panic(fmt.Errorf("child %q depends on %q which is not supervised, dependencies must be supervised before the children depending on them", name, dependency))
+		}
+	}
+
+	if _, ok := child.(ReadinessChecker); ok {
+		a.AddReadinessCheck(name, func(_ context.Context) error {
+			if checker, ok := supervised.current().(ReadinessChecker); ok && checker.IsReady() {
+				return nil
+			}
+
+			return errors.New("not ready")
+		})
+	}
+
+	if _, ok := child.(ConfigReloader); ok {
+		a.OnConfigChange(func(diff ConfigDiff) error {
+			if reloader, ok := supervised.current().(ConfigReloader); ok {
+				if err := reloader.ReloadConfig(diff); err != nil {
+					return fmt.Errorf("child %q: %w", name, err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.children = append(a.children, supervised)
+	return supervised
+}
+
+// current returns the running instance of the child, it changes when the child is restarted.
+func (c *applicationChild) current() Shutter {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.shutter
+}
+
+func (c *applicationChild) setCurrent(child Shutter) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.shutter = child
+}
+
+// markTerminated flags the child as terminated for good, no more restart will happen.
+func (c *applicationChild) markTerminated() {
+	c.terminatedOnce.Do(func() { close(c.terminated) })
+}
+
+func (c *applicationChild) isReady() bool {
+	if !c.started.Load() || c.isTerminated() {
+		return false
+	}
+
+	if checker, ok := c.current().(ReadinessChecker); ok {
+		return checker.IsReady()
+	}
+
+	return true
+}
+
+func (c *applicationChild) isTerminated() bool {
+	select {
+	case <-c.terminated:
+		return true
+	default:
+		return false
+	}
+}
+
+// shutdown gracefully shuts down the child and waits for its termination, bounded by
+// its shutdown timeout if any.
+func (c *applicationChild) shutdown() {
+	go c.current().Shutdown(nil)
+
+	if c.options.shutdownTimeout <= 0 {
+		<-c.terminated
+		return
+	}
+
+	select {
+	case <-c.terminated:
+	case <-time.After(c.options.shutdownTimeout):
+		zlog.Warn("child did not terminate within its shutdown timeout, moving on",
+			zap.String("child", c.name),
+			zap.Duration("timeout", c.options.shutdownTimeout),
+		)
+	}
+}
+
+// shutdownChildren shuts down the children stage by stage, see [Application.SuperviseNamed].
+func (a *Application) shutdownChildren() {
+	children := a.childrenSnapshot()
+	stages := shutdownStages(children)
+
+	sort.SliceStable(children, func(i, j int) bool {
+		return stages[children[i]] < stages[children[j]]
+	})
+
+	for start := 0; start < len(children); {
+		end := start
+		for end < len(children) && stages[children[end]] == stages[children[start]] {
+			end++
+		}
+
+		zlog.Debug("shutting down children stage", zap.Int("stage", stages[children[start]]), zap.Int("children", end-start))
+
+		wg := sync.WaitGroup{}
+		for _, child := range children[start:end] {
+			wg.Add(1)
+			go func(child *applicationChild) {
+				defer wg.Done()
+				child.shutdown()
+			}(child)
+		}
+		wg.Wait()
+
+		start = end
+	}
+}
+
+// shutdownStages returns the effective shutdown stage of each child, dependencies being
+// pushed to a later stage than the children depending on them.
+func shutdownStages(children []*applicationChild) map[*applicationChild]int {
+	stages := make(map[*applicationChild]int, len(children))
+	byName := make(map[string]*applicationChild, len(children))
+	for _, child := range children {
+		stages[child] = child.options.shutdownStage
+		byName[child.name] = child
+	}
+
+	// Dependencies are supervised before the children depending on them, walking the children
+	// in reverse order settles the stage of a child before it's pushed to its dependencies
+	for i := len(children) - 1; i >= 0; i-- {
+		child := children[i]
+		for _, name := range child.options.dependsOn {
+			if dependency := byName[name]; stages[dependency] <= stages[child] {
+				stages[dependency] = stages[child] + 1
+			}
+		}
+	}
+
+	return stages
+}
+
+var errApplicationTerminating = errors.New("application terminating")
+
+// waitForDependencies waits until all dependencies of the child are ready, see [WithDependsOn].
+func (a *Application) waitForDependencies(c *applicationChild) error {
+	if len(c.options.dependsOn) == 0 {
+		return nil
+	}
+
+	var timeout <-chan time.Time
+	if c.options.startupTimeout > 0 {
+		timer := time.NewTimer(c.options.startupTimeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	zlog.Debug("waiting for child dependencies", zap.String("child", c.name), zap.Strings("depends_on", c.options.dependsOn))
+
+	for {
+		pending := a.pendingDependencies(c)
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-a.shutter.Terminating():
+			return errApplicationTerminating
+		case <-timeout:
+			return fmt.Errorf("child %q dependencies %s not ready within %s", c.name, strings.Join(pending, ", "), c.options.startupTimeout)
+		}
+	}
+}
+
+// pendingDependencies returns the dependencies of the child not ready yet. A terminated
+// dependency is never ready, its termination terminates the application which ends the wait.
+func (a *Application) pendingDependencies(c *applicationChild) (pending []string) {
+	byName := map[string]*applicationChild{}
+	for _, child := range a.childrenSnapshot() {
+		byName[child.name] = child
+	}
+
+	for _, name := range c.options.dependsOn {
+		if !byName[name].isReady() {
+			pending = append(pending, name)
+		}
+	}
+
+	return pending
+}
+
+// defaultChildName names the child after its type, suffixed by its occurrence when children
+// of the same type are already supervised.
+func (a *Application) defaultChildName(child Shutter) string {
+	supervisedNames := map[string]bool{}
+	for _, existing := range a.childrenSnapshot() {
+		supervisedNames[existing.name] = true
+	}
+
+	typeName := fmt.Sprintf("%T", child)
+	name := typeName
+	for occurrence := 2; supervisedNames[name]; occurrence++ {
+		name = fmt.Sprintf("%s#%d", typeName, occurrence)
+	}
+
+	return name
+}
+
+func (a *Application) runningChildren() (names []string) {
+	for _, child := range a.childrenSnapshot() {
+		if !child.isTerminated() {
+			names = append(names, child.name)
+		}
+	}
+
+	return
+}
+
+func (a *Application) childrenSnapshot() []*applicationChild {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	children := make([]*applicationChild, len(a.children))
+	copy(children, a.children)
+	return children
+}
+
+// SuperviseAndRestart supervises and starts, like [Application.SuperviseNamedAndStart], the
+// child created by `factory` and restarts it according to its [RestartPolicy] when it
+// terminates, each restart creating a new instance through `factory`. Restarts are delayed
+// by an exponential backoff, see [WithRestartBackoff].
+//
+// The application terminates with the child's error once the child terminates and must
+// not be restarted anymore, either due to its policy or because its restart budget is
+// exhausted (see [WithMaxRestarts]). By default, the restart budget is unlimited: a child
+// crashing on each start is restarted forever, every minute once the backoff reached its
+// maximum, use [WithMaxRestarts] to terminate the application instead.
+//
+// The child is not ready (see [WithDependsOn]) from its termination until its next instance
+// is started.
+func (a *Application) SuperviseAndRestart(name string, factory func() Shutter, opts ...ChildOption) {
+	child := factory()
+	run := a.childRunner(child)
+
+	go a.superviseRestarts(a.addChild(name, child, opts), factory, child, run)
+}
+
+func (a *Application) superviseRestarts(c *applicationChild, factory func() Shutter, child Shutter, run func() error) {
+	if err := a.waitForDependencies(c); err != nil {
+		c.markTerminated()
+		if !errors.Is(err, errApplicationTerminating) {
+			a.shutter.Shutdown(err)
+		}
+
+		return
+	}
+
+	backoff := c.options.restartBackoff
+	restarts := 0
+
+	for {
+		terminated := make(chan error, 1)
+		child.OnTerminated(func(err error) { terminated <- err })
+
+		startedAt := time.Now()
+		go a.start(c, child, run)
+
+		err := <-terminated
+
+		// Not ready while waiting for the next instance, the children depending on it see it down
+		c.started.Store(false)
+		if a.shutter.IsTerminating() {
+			c.markTerminated()
+			return
+		}
+
+		// A child that stayed up long enough is healthy again, its crashes are not related to
+		// the previous ones anymore
+		if time.Since(startedAt) > c.options.maxRestartBackoff {
+			backoff = c.options.restartBackoff
+			restarts = 0
+		}
+
+		if !c.options.restartPolicy.shouldRestart(err) {
+			c.markTerminated()
+			a.shutter.Shutdown(err)
+			return
+		}
+
+		if c.options.maxRestarts >= 0 && restarts >= c.options.maxRestarts {
+			zlog.Error("child restart budget exhausted, terminating application",
+				zap.String("child", c.name),
+				zap.Int("max_restarts", c.options.maxRestarts),
+				zap.Error(err),
+			)
+
+			c.markTerminated()
+			a.shutter.Shutdown(err)
+			return
+		}
+
+		zlog.Warn("child terminated, restarting it",
+			zap.String("child", c.name),
+			zap.Stringer("policy", c.options.restartPolicy),
+			zap.Int("restart", restarts+1),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-time.After(backoff):
+		case <-a.shutter.Terminating():
+			c.markTerminated()
+			return
+		}
+
+		restarts++
+		if backoff *= 2; backoff > c.options.maxRestartBackoff {
+			backoff = c.options.maxRestartBackoff
+		}
+
+		child = factory()
+		run = a.childRunner(child)
+		c.setCurrent(child)
+
+		// The application might have started shutting down its children before the new instance
+		// became current, it must not be started then
+		if a.shutter.IsTerminating() {
+			c.markTerminated()
+			return
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/streamingfast/shutter"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+)
+
+func TestApplication_ShutdownStages(t *testing.T) {
+	var lock sync.Mutex
+	var order []string
+
+	newChild := func(name string, block chan struct{}) *shutter.Shutter {
+		return shutter.New(shutter.RegisterOnTerminating(func(_ error) {
+			lock.Lock()
+			order = append(order, name)
+			lock.Unlock()
+
+			if block != nil {
+				<-block
+			}
+		}))
+	}
+
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	app := NewApplication(context.Background())
+	app.SuperviseNamed("db", newChild("db", nil), WithShutdownStage(2))
+	app.SuperviseNamed("http", newChild("http", nil), WithShutdownStage(0))
+	app.SuperviseNamed("worker", newChild("worker", stuck), WithShutdownStage(1), WithShutdownTimeout(10*time.Millisecond))
+
+	app.shutter.Shutdown(nil)
+
+	assert.Equal(t, []string{"http", "worker", "db"}, order)
+	assert.Equal(t, []string{"worker"}, app.runningChildren())
+}
+
+type flakyChild struct {
+	*shutter.Shutter
+	err     error
+	healthy bool
+	runFor  time.Duration
+}
+
+func (c *flakyChild) Run() error {
+	if c.healthy {
+		<-c.Terminating()
+		return nil
+	}
+
+	time.Sleep(c.runFor)
+
+	c.Shutdown(c.err)
+	return c.err
+}
+
+func TestApplication_SuperviseAndRestart(t *testing.T) {
+	failure := errors.New("connection lost")
+	healthy := errors.New("healthy")
+
+	tests := []struct {
+		name          string
+		errs          []error
+		runFor        time.Duration
+		opts          []ChildOption
+		wantInstances int
+		wantErr       error
+	}{
+		{"budget exhausted", []error{failure, failure, failure, failure}, 0, []ChildOption{WithMaxRestarts(2)}, 3, failure},
+		{"budget reset by long runs", []error{failure, failure, failure, failure, healthy}, 50 * time.Millisecond, []ChildOption{WithMaxRestarts(1)}, 5, nil},
+		{"recovers", []error{failure, failure, healthy}, 0, nil, 3, nil},
+		{"never", []error{failure}, 0, []ChildOption{WithRestartPolicy(RestartNever)}, 1, failure},
+		{"on failure", []error{nil}, 0, nil, 1, nil},
+		{"always", []error{nil, nil, failure}, 0, []ChildOption{WithRestartPolicy(RestartAlways), WithMaxRestarts(2)}, 3, failure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var instances atomic.Int32
+
+			app := NewApplication(context.Background())
+			app.SuperviseAndRestart("worker", func() Shutter {
+				err := tt.errs[instances.Inc()-1]
+				return &flakyChild{Shutter: shutter.New(), err: err, healthy: err == healthy, runFor: tt.runFor}
+			}, append(tt.opts, WithRestartBackoff(time.Millisecond, 20*time.Millisecond))...)
+
+			// A healthy instance runs until the application is shut down
+			if tt.errs[len(tt.errs)-1] == healthy {
+				assert.Eventually(t, func() bool { return instances.Load() == int32(tt.wantInstances) }, 2*time.Second, time.Millisecond)
+				app.shutter.Shutdown(nil)
+			}
+
+			select {
+			case <-app.shutter.Terminated():
+			case <-time.After(2 * time.Second):
+				t.Fatal("application did not terminate")
+			}
+
+			assert.Equal(t, int32(tt.wantInstances), instances.Load())
+			assert.Equal(t, tt.wantErr, app.shutter.Err())
+			assert.Empty(t, app.runningChildren())
+		})
+	}
+}
+
+type dependencyChild struct {
+	*shutter.Shutter
+	name    string
+	ready   atomic.Bool
+	events  *eventLog
+	blockOn chan struct{}
+}
+
+func (c *dependencyChild) IsReady() bool { return c.ready.Load() }
+
+func (c *dependencyChild) Run() {
+	c.events.add("start " + c.name)
+	if c.blockOn != nil {
+		<-c.blockOn
+	}
+
+	c.ready.Store(true)
+}
+
+type eventLog struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return append([]string(nil), l.events...)
+}
+
+func TestApplication_DependencyOrder(t *testing.T) {
+	events := &eventLog{}
+	newChild := func(name string, blockOn chan struct{}) *dependencyChild {
+		child := &dependencyChild{name: name, events: events, blockOn: blockOn}
+		child.Shutter = shutter.New(shutter.RegisterOnTerminating(func(_ error) { events.add("stop " + name) }))
+		return child
+	}
+
+	dbReady := make(chan struct{})
+
+	app := NewApplication(context.Background())
+	app.SuperviseNamedAndStart("db", newChild("db", dbReady))
+	app.SuperviseNamedAndStart("cache", newChild("cache", nil), WithDependsOn("db"))
+	app.SuperviseNamedAndStart("api", newChild("api", nil), WithDependsOn("cache", "db"))
+
+	assert.Eventually(t, func() bool { return len(events.get()) == 1 }, time.Second, time.Millisecond)
+	assert.Never(t, func() bool { return len(events.get()) > 1 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	close(dbReady)
+	assert.Eventually(t, func() bool { return len(events.get()) == 3 }, time.Second, time.Millisecond)
+
+	app.shutter.Shutdown(nil)
+	assert.Equal(t, []string{"start db", "start cache", "start api", "stop api", "stop cache", "stop db"}, events.get())
+}
+
+func TestApplication_DependencyFailures(t *testing.T) {
+	t.Run("dependency terminated", func(t *testing.T) {
+		app := NewApplication(context.Background())
+		app.SuperviseNamedAndStart("db", &flakyChild{Shutter: shutter.New(), err: errors.New("connection refused")})
+		app.SuperviseNamedAndStart("api", &flakyChild{Shutter: shutter.New(), healthy: true}, WithDependsOn("db"))
+
+		<-app.shutter.Terminated()
+		assert.EqualError(t, app.shutter.Err(), "connection refused", "the dependency's error terminates the application")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		events := &eventLog{}
+		db := &dependencyChild{Shutter: shutter.New(), name: "db", events: events, blockOn: make(chan struct{})}
+
+		app := NewApplication(context.Background())
+		app.SuperviseNamedAndStart("db", db)
+		app.SuperviseNamedAndStart("api", &flakyChild{Shutter: shutter.New(), healthy: true}, WithDependsOn("db"), WithStartupTimeout(100*time.Millisecond))
+
+		<-app.shutter.Terminated()
+		assert.EqualError(t, app.shutter.Err(), `child "api" dependencies db not ready within 100ms`)
+	})
+
+	t.Run("unknown dependency", func(t *testing.T) {
+		app := NewApplication(context.Background())
+
+		assert.PanicsWithError(t, `child "api" depends on "db" which is not supervised, dependencies must be supervised before the children depending on them`, func() {
+			app.SuperviseNamedAndStart("api", &flakyChild{Shutter: shutter.New(), healthy: true}, WithDependsOn("db"))
+		})
+		assert.Empty(t, app.childrenSnapshot())
+	})
+
+	t.Run("self dependency", func(t *testing.T) {
+		app := NewApplication(context.Background())
+
+		assert.PanicsWithError(t, `child "api" depends on "api" which is not supervised, dependencies must be supervised before the children depending on them`, func() {
+			app.SuperviseNamedAndStart("api", &flakyChild{Shutter: shutter.New(), healthy: true}, WithDependsOn("api"))
+		})
+	})
+}
+
+func TestApplication_ChildNames(t *testing.T) {
+	app := NewApplication(context.Background())
+	app.Supervise(shutter.New())
+	app.Supervise(shutter.New())
+	app.SuperviseNamed("*shutter.Shutter#3", shutter.New())
+	app.Supervise(shutter.New())
+
+	var names []string
+	for _, child := range app.childrenSnapshot() {
+		names = append(names, child.name)
+	}
+
+	assert.Equal(t, []string{"*shutter.Shutter", "*shutter.Shutter#2", "*shutter.Shutter#3", "*shutter.Shutter#4"}, names)
+	assert.Equal(t, 30*time.Second, app.childrenSnapshot()[0].options.shutdownTimeout, "a stuck child must not block the later shutdown stages forever")
+	assert.PanicsWithError(t, `child "*shutter.Shutter#2" is already supervised, children names must be unique`, func() {
+		app.SuperviseNamed("*shutter.Shutter#2", shutter.New())
+	})
+}
+
+func TestApplication_SuperviseAndRestart_NotReadyWhileRestarting(t *testing.T) {
+	app := NewApplication(context.Background())
+	defer app.shutter.Shutdown(nil)
+
+	app.SuperviseAndRestart("worker", func() Shutter {
+		return &flakyChild{Shutter: shutter.New(), err: errors.New("connection lost"), runFor: 50 * time.Millisecond}
+	}, WithRestartBackoff(time.Hour, time.Hour))
+
+	worker := app.childrenSnapshot()[0]
+	assert.Eventually(t, worker.isReady, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return !worker.isReady() }, time.Second, time.Millisecond)
+	assert.Never(t, worker.isReady, 50*time.Millisecond, 5*time.Millisecond, "the child is waiting for its restart")
+	assert.False(t, worker.isTerminated())
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -81,7 +82,13 @@ func RangeArgs(min int, max int) Args {
 type Args cobra.PositionalArgs
 
 func (a Args) Apply(cmd *cobra.Command) {
-	cmd.Args = cobra.PositionalArgs(a)
+	cmd.Args = func(cmd *cobra.Command, args []string) error {
+		if err := a(cmd, args); err != nil {
+			return &UsageError{Err: err}
+		}
+
+		return nil
+	}
 }
 
 func Description(value string) description {
@@ -122,6 +129,15 @@ func Execute(f func(cmd *cobra.Command, args []string) error) execute {
 	return execute(f)
 }
 
+// ExecuteContext is like [Execute] but the handler receives the command's context, which is
+// cancelled on the first termination signal when the command is executed through [Run], see
+// [ConfigureSignals].
+func ExecuteContext(f func(ctx context.Context, cmd *cobra.Command, args []string) error) execute {
+	return execute(func(cmd *cobra.Command, args []string) error {
+		return f(cmd.Context(), cmd, args)
+	})
+}
+
 type execute func(cmd *cobra.Command, args []string) error
 
 func (e execute) Apply(cmd *cobra.Command) {
@@ -186,11 +202,14 @@ func Example(value string) example {
 // Priority is:
 // Flag definition via CLI overrides everyone else (--<flag>)
 // Environment overrides values provided by config file or defaults ({PREFIX}_{ENV_KEY})
-// Config file (if configure separately) overrides defaults values (if configure separately) (--<flag>)
+// Config file (if configured separately, see [ConfigureConfigFile]) overrides defaults values
 // Defaults values defined on the flag definition directly
-func ConfigureViper(envPrefix string) CommandOption {
+//
+// The global viper singleton is used, see [ConfigureViperInstance] to bind into your own
+// instance instead.
+func ConfigureViper(envPrefix string, opts ...ViperOption) CommandOption {
 	return AfterAllHook(func(cmd *cobra.Command) {
-		ConfigureViperForCommand(cmd, envPrefix)
+		ConfigureViperForCommand(cmd, envPrefix, opts...)
 	})
 }
 
@@ -279,22 +298,33 @@ func (e example) Apply(cmd *cobra.Command) {
 	cmd.Example = string(e)
 }
 
+// Run creates the root command with [Root] and executes it, exiting the process through [Exit]
+// with the code of the error if any, see [ExitCode].
+//
+// The commands are executed with a context cancelled on the first SIGINT or SIGTERM, see
+// [ExecuteContext] and [ConfigureSignals]. The signal handler is only registered once the
+// context is waited on, so commands not using it are still killed on Ctrl-C.
+//
+// Panics of the commands' handlers are recovered, logged and then the process exits with
+// [ExitCodePanic] running the registered exit handlers, use [PanicAsCommandError] to have
+// them handled like regular errors.
 func Run(usage, short string, opts ...CommandOption) {
 	cmd := Root(usage, short, opts...)
 
 	visitAllCommands(cmd, func(cmd *cobra.Command) {
 		if cmd.RunE != nil {
-			cmd.RunE = silenceUsageOnError(cmd.RunE)
+			cmd.RunE = silenceUsageOnError(recoverPanics(cmd.RunE))
 			cmd.SilenceUsage = false
 		}
 	})
 
-	err := cmd.Execute()
+	ctx, stop := runContext(cmd)
+	err := cmd.ExecuteContext(ctx)
+	stop()
 
-	// FIXME: What is the right behavior on error from here?
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
-		Exit(1)
+		Exit(ExitCode(cobraUsageError(err)))
 	}
 }
 
@@ -309,12 +339,19 @@ func Root(usage, short string, opts ...CommandOption) *cobra.Command {
 	beforeAllHook := BeforeAllHook(func(cmd *cobra.Command) {
 		cmd.SilenceErrors = true
 		cmd.SilenceUsage = true
+		cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+			return &UsageError{Err: err}
+		})
 		if short != "" {
 			cmd.Short = strings.TrimSpace(dedent.Dedent(short))
 		}
 	})
 
-	return command(nil, usage, short, append([]CommandOption{beforeAllHook}, opts...)...)
+	// Installed last so that any persistent pre-run function defined by the options is chained
+	installHooks := AfterAllHook(installPreRunHooks)
+
+	opts = append([]CommandOption{beforeAllHook}, opts...)
+	return command(nil, usage, short, append(opts, AfterAllHook(describeArgs), installHooks, AfterAllHook(checkValidatedFlags))...)
 }
 
 func command(execute func(cmd *cobra.Command, args []string) error, usage, short string, opts ...CommandOption) *cobra.Command {
@@ -362,7 +399,8 @@ type OnCommandErrorHandler func(err error)
 
 // OnCommandError intercepts error returned when running your `cobra.Command.RunE`
 // handler and enable you to do something with it, like logging it. Once your handler
-// has finish running, the process will exit with code 1.
+// has finish running, the process will exit with the code of the error as returned
+// by [ExitCode], so 1 unless an [ExitError] is returned.
 //
 // You are free to exit yourself in your own handler, for example if some error
 // should still exit with code 0.
@@ -370,7 +408,7 @@ func OnCommandError(onError func(err error)) CommandOption {
 	return AfterAllHook(func(cmd *cobra.Command) {
 		handler := OnCommandErrorHandler(func(cause error) {
 			onError(cause)
-			Exit(1)
+			Exit(ExitCode(cause))
 		})
 
 		visitAllCommands(cmd, func(iterated *cobra.Command) {
@@ -378,15 +416,16 @@ func OnCommandError(onError func(err error)) CommandOption {
 		})
 
 		// We keep it inside because this is called very late and others could
-		// have configured the OnAssertionFailure.
+		// have configured the OnAssertionFailure. The exit is performed by the
+		// assertion itself so that its code is honored.
 		if OnAssertionFailure == nil {
-			OnAssertionFailure = func(message string) { handler(errors.New(message)) }
+			OnAssertionFailure = func(message string) { onError(errors.New(message)) }
 		}
 	})
 }
 
 // OnCommandErrorLogAndExit logs the error to the logger, sync the logger and
-// exit with 1. It also intercepts assertion error produced by this library through
+// exit with the error's code, see [OnCommandError]. It also intercepts assertion error produced by this library through
 // `cli.Ensure` and `cli.NoError`.
 func OnCommandErrorLogAndExit(logger *zap.Logger) CommandOption {
 	if OnAssertionFailure == nil {
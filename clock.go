@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the timers used by [SignalHandler] so that its delays can be controlled in
+// tests, see [WithClock] and [FakeClock].
+type Clock interface {
+	// AfterFunc calls `f` in its own goroutine once `d` elapsed.
+	AfterFunc(d time.Duration, f func())
+}
+
+type systemClock struct{}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) {
+	time.AfterFunc(d, f)
+}
+
+// FakeClock is a [Clock] whose time only moves forward through [FakeClock.Advance].
+type FakeClock struct {
+	lock    sync.Mutex
+	elapsed time.Duration
+	timers  []fakeTimer
+}
+
+type fakeTimer struct {
+	at time.Duration
+	f  func()
+}
+
+func NewFakeClock() *FakeClock {
+	return &FakeClock{}
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.timers = append(c.timers, fakeTimer{at: c.elapsed + d, f: f})
+}
+
+// Advance moves the clock forward by `d`, calling the functions of the timers that expired in
+// expiration order. Unlike [time.AfterFunc], they are called synchronously.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	c.elapsed += d
+
+	var expired, pending []fakeTimer
+	for _, timer := range c.timers {
+		if timer.at <= c.elapsed {
+			expired = append(expired, timer)
+		} else {
+			pending = append(pending, timer)
+		}
+	}
+	c.timers = pending
+	c.lock.Unlock()
+
+	sort.SliceStable(expired, func(i, j int) bool { return expired[i].at < expired[j].at })
+	for _, timer := range expired {
+		timer.f()
+	}
+}
+
+// PendingTimers returns the number of timers that did not expire yet.
+func (c *FakeClock) PendingTimers() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return len(c.timers)
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// CompletionFunc returns the completion candidates for the value being typed, `toComplete`,
+// along with a directive controlling the shell's behavior. A candidate can be followed by a
+// tab and a description that is shown by shells supporting it (`network\tDescription`).
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// ConfigureCompletion mounts a `completion <shell>` command on the [cobra.Command] (usually
+// the root) that prints the completion script for bash, zsh, fish or PowerShell.
+//
+// Completion of flags and positional arguments values is customized with [CompleteFlag] and
+// [CompleteArgs].
+func ConfigureCompletion() CommandOption {
+	return CommandOptionFunc(func(parent *cobra.Command) {
+		Command(completionE,
+			"completion",
+			"Generate the autocompletion script for the specified shell",
+			internalCommand(),
+			Description(fmt.Sprintf(`
+				Generate the autocompletion script of %[1]s for the specified shell.
+
+				Bash (requires the 'bash-completion' package):
+				  source <(%[1]s completion bash)
+
+				Zsh:
+				  %[1]s completion zsh > "${fpath[1]}/_%[1]s"
+
+				Fish:
+				  %[1]s completion fish | source
+
+				PowerShell:
+				  %[1]s completion powershell | Out-String | Invoke-Expression
+			`, parent.Root().Name())),
+			Arg[string]("shell", "The shell to generate the script for, one of "+strings.Join(completionShells, ", ")),
+			CompleteArgs(CompleteValues(completionShells...)),
+		).Apply(parent)
+	})
+}
+
+// CompleteFlag registers `complete` as the completion function of flag `name`. It must be
+// listed after the flag definition on the same command, a flag not found leads to a panic.
+func CompleteFlag(name string, complete CompletionFunc) CommandOption {
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		if err := cmd.RegisterFlagCompletionFunc(name, complete); err != nil {
+			panic(fmt.Errorf("cli.CompleteFlag must be listed after the flag definition: %w", err))
+		}
+	})
+}
+
+// CompleteArgs registers `complete` as the completion function of the command's positional
+// arguments, `args` received by `complete` are the arguments already typed.
+func CompleteArgs(complete CompletionFunc) CommandOption {
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		cmd.ValidArgsFunction = complete
+	})
+}
+
+// CompleteValues completes with the `values` starting with the text being typed, values can
+// have a description as documented on [CompletionFunc]. File completion is disabled.
+func CompleteValues(values ...string) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		var candidates []string
+		for _, value := range values {
+			if strings.HasPrefix(value, toComplete) {
+				candidates = append(candidates, value)
+			}
+		}
+
+		return candidates, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteFiles completes with file paths, restricted to the given `extensions` (without
+// the leading dot) if any.
+func CompleteFiles(extensions ...string) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(extensions) == 0 {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+
+		return extensions, cobra.ShellCompDirectiveFilterFileExt
+	}
+}
+
+// CompleteDirs completes with directory paths.
+func CompleteDirs() CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveFilterDirs
+	}
+}
+
+func completionE(cmd *cobra.Command, _ []string) error {
+	out := cmd.OutOrStdout()
+
+	switch shell := MustGetArg[string](cmd, "shell"); shell {
+	case "bash":
+		return cmd.Root().GenBashCompletion(out)
+	case "zsh":
+		return cmd.Root().GenZshCompletion(out)
+	case "fish":
+		return cmd.Root().GenFishCompletion(out, true)
+	case "powershell":
+		return cmd.Root().GenPowerShellCompletionWithDesc(out)
+	default:
+		return usageErrorf("unsupported shell %q, must be one of %q", shell, completionShells)
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletion(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		return Root("acme", "CLI sample application",
+			Command(func(cmd *cobra.Command, args []string) error { return nil },
+				"deploy",
+				"Deploy the application",
+				Flags(func(flags *pflag.FlagSet) {
+					flags.String("network", "", "Network to deploy to")
+					flags.String("manifest", "", "Manifest file")
+				}),
+				CompleteFlag("network", CompleteValues("mainnet\tProduction network", "testnet", "devnet")),
+				CompleteFlag("manifest", CompleteFiles("yaml")),
+				Arg[string]("target", "Deployment target"),
+				CompleteArgs(CompleteValues("staging", "production")),
+			),
+			ConfigureCompletion(),
+			PersistentFlags(func(flags *pflag.FlagSet) { flags.String("api-key", "", "API key") }),
+			Required("api-key"),
+		)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"flag values", []string{"__complete", "deploy", "--network", "m"}, "mainnet\tProduction network\n:4\n"},
+		{"flag files", []string{"__complete", "deploy", "--manifest", ""}, "yaml\n:8\n"},
+		{"args", []string{"__complete", "deploy", "s"}, "staging\n:4\n"},
+		{"shells", []string{"__complete", "completion", ""}, "bash\nzsh\nfish\npowershell\n:4\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			root := newRoot()
+			root.SetOut(&out)
+			root.SetArgs(tt.args)
+			require.NoError(t, root.Execute())
+
+			assert.True(t, strings.HasPrefix(out.String(), tt.want), "got %q", out.String())
+		})
+	}
+}
+
+func TestCompletion_Script(t *testing.T) {
+	for _, shell := range completionShells {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+
+			root := Root("acme", "CLI sample application",
+				Command(func(cmd *cobra.Command, args []string) error { return nil }, "deploy", "Deploy the application"),
+				ConfigureCompletion(),
+				PersistentFlags(func(flags *pflag.FlagSet) { flags.String("api-key", "", "API key") }),
+				Required("api-key"),
+			)
+			root.SetOut(&out)
+			root.SetArgs([]string{"completion", shell})
+			require.NoError(t, root.Execute())
+
+			assert.Contains(t, out.String(), "acme")
+		})
+	}
+
+	root := Root("acme", "CLI sample application", ConfigureCompletion())
+	root.SetArgs([]string{"completion", "tcsh"})
+	assert.EqualError(t, root.Execute(), `unsupported shell "tcsh", must be one of ["bash" "zsh" "fish" "powershell"]`)
+}
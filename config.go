@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+var annotationConfigSettings = "config-settings"
+var annotationConfigFile = "config-file"
+
+// ConfigFileTypes lists the extensions, in search order, recognized by [ConfigureConfigFile].
+var ConfigFileTypes = []string{"yaml", "yml", "toml", "json"}
+
+// ConfigureConfigFile adds a `--config` persistent flag on the [cobra.Command] and loads the
+// configuration file it points to into viper right before the command executes.
+//
+// When `--config` is not provided, the file `<appName>.<ext>` is searched in the working
+// directory and then `config.<ext>` in `$XDG_CONFIG_HOME/<appName>` (`~/.config/<appName>`
+// if `XDG_CONFIG_HOME` is not set), where `<ext>` is one of [ConfigFileTypes]. Not finding
+// any file is not an error, pointing `--config` to a missing file is.
+//
+// The keys of the file follow the layout produced by [ConfigureViper], nested or flat, so for
+// the hierarchy documented there, the following files are equivalent:
+//
+//	global:
+//	  auth: "token"
+//	tools:
+//	  global:
+//	    dev: true
+//	  read:
+//	    skip-errors: true
+//
+//	global-auth: "token"
+//	tools-global-dev: true
+//	tools-read-skip-errors: true
+//
+// You should use it in conjunction with [ConfigureViper], the config file being the layer
+// between environment variables and flag's default values. The `--config` flag itself is
+// rebound, so `{PREFIX}_GLOBAL_CONFIG` environment variable can be used to provide it.
+func ConfigureConfigFile(appName string) CommandOption {
+	return CommandOptionFunc(func(root *cobra.Command) {
+		root.PersistentFlags().String("config", "", FlagDescription(`
+			Configuration file to load, if not provided, searches for '%s.<ext>' in the working
+			directory and for 'config.<ext>' in '$XDG_CONFIG_HOME/%s' where '<ext>' is one of %s
+		`, appName, appName, strings.Join(ConfigFileTypes, ", ")))
+
+		addPreRunHook(root, preRunPhaseLoad, func(cmd *cobra.Command) error {
+			v := ViperFor(cmd)
+
+			path, err := findConfigFile(root, v, appName)
+			if err != nil {
+				return ConfigError(err)
+			}
+
+			if path == "" {
+				zlog.Debug("no config file found")
+				return nil
+			}
+
+			zlog.Debug("loading config file", zap.String("path", path))
+			if err := loadConfigFile(root, v, path); err != nil {
+				return ConfigError(err)
+			}
+
+			setCommandAnnotation(root, annotationConfigFile, path)
+			return nil
+		})
+	})
+}
+
+// ConfigFileUsed returns the path of the config file loaded by [ConfigureConfigFile] for
+// the command's tree, if any.
+func ConfigFileUsed(cmd *cobra.Command) (string, bool) {
+	path, found := getCommandAnnotation(cmd.Root(), annotationConfigFile)
+	if !found {
+		return "", false
+	}
+
+	return path.(string), true
+}
+
+func findConfigFile(root *cobra.Command, v *viper.Viper, appName string) (string, error) {
+	if path := rootFlagValue(root, v, "config"); path != "" {
+		if !FileExists(path) {
+			return "", fmt.Errorf("config file %q does not exist", path)
+		}
+
+		return path, nil
+	}
+
+	var candidates []string
+	for _, configType := range ConfigFileTypes {
+		candidates = append(candidates, appName+"."+configType)
+	}
+
+	if configHome := xdgConfigHome(); configHome != "" {
+		for _, configType := range ConfigFileTypes {
+			candidates = append(candidates, filepath.Join(configHome, appName, "config."+configType))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if FileExists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	return "", nil
+}
+
+// rootFlagValue returns the value of the root's persistent flag `name`, going through viper
+// if the flag was rebound so that it can be provided through environment variable too.
+func rootFlagValue(root *cobra.Command, v *viper.Viper, name string) string {
+	flag := root.PersistentFlags().Lookup(name)
+	if key, found := reboundKey(flag); found {
+		return v.GetString(key)
+	}
+
+	return flag.Value.String()
+}
+
+func xdgConfigHome() string {
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		return configHome
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".config")
+}
+
+// loadConfigFile reads the config file at `path` and replaces the config layer of `v` with
+// it. Keys of rebound flags are made available under both their nested form (`<cmd1>.<cmd2>.<flag>`)
+// and their flat form (`<cmd1>-<cmd2>-<flag>`), whichever was used in the file.
+func loadConfigFile(root *cobra.Command, v *viper.Viper, path string) error {
+	settings, err := readConfigFile(root, path)
+	if err != nil {
+		return err
+	}
+
+	if err := replaceConfig(v, settings); err != nil {
+		return err
+	}
+
+	setCommandAnnotation(root, annotationConfigSettings, settings)
+	return nil
+}
+
+func readConfigFile(root *cobra.Command, path string) (map[string]any, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file %q: %w", path, err)
+	}
+
+	configType := strings.TrimPrefix(filepath.Ext(path), ".")
+	if !isConfigFileType(configType) {
+		return nil, fmt.Errorf("config file %q has unsupported type %q, must be one of %s", path, configType, strings.Join(ConfigFileTypes, ", "))
+	}
+
+	raw := viper.New()
+	raw.SetConfigType(configType)
+	if err := raw.ReadConfig(bytes.NewReader(content)); err != nil {
+		return nil, fmt.Errorf("parse config file %q: %w", path, err)
+	}
+
+	// Both forms are bound by ConfigureViper, so each value is made available under both
+	settings := raw.AllSettings()
+	visitReboundFlags(root, func(_ *cobra.Command, _ *pflag.Flag, key string) {
+		flatKey := dashKey(key)
+		switch {
+		case flatKey == key:
+		case !raw.InConfig(key) && raw.InConfig(flatKey):
+			setNestedValue(settings, strings.Split(key, "."), raw.Get(flatKey))
+		case raw.InConfig(key) && !raw.InConfig(flatKey):
+			settings[flatKey] = raw.Get(key)
+		}
+	})
+
+	return settings, nil
+}
+
+// replaceConfig replaces the config layer of `v` with `settings`.
+func replaceConfig(v *viper.Viper, settings map[string]any) error {
+	// Viper has no API to replace the config layer, reading an empty document resets it
+	v.SetConfigType("json")
+	if err := v.ReadConfig(strings.NewReader("{}")); err != nil {
+		return fmt.Errorf("reset config: %w", err)
+	}
+
+	return v.MergeConfigMap(settings)
+}
+
+func isConfigFileType(in string) bool {
+	for _, configType := range ConfigFileTypes {
+		if configType == in {
+			return true
+		}
+	}
+
+	return false
+}
+
+func setNestedValue(settings map[string]any, path []string, value any) {
+	current := settings
+	for _, segment := range path[:len(path)-1] {
+		next, ok := current[segment].(map[string]any)
+		if !ok {
+			next = map[string]any{}
+			current[segment] = next
+		}
+
+		current = next
+	}
+
+	current[path[len(path)-1]] = value
+}
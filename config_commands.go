@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var annotationInternalCommand = "internal-command"
+
+// ConfigCommands mounts a `config` group of commands on the [cobra.Command] (usually the root)
+// to inspect the configuration of your application:
+//
+//	config show [--format table|json]      Prints the effective configuration merged from environment, config file and defaults
+//	config env                             Lists every environment variable along with the flag it maps to
+//	config template [--format yaml|toml]   Generates a commented config file from all flag usages and defaults
+//
+// The commands rely on the flags rebound by [ConfigureViper] and are themselves excluded from
+// the rebinding. Use [ConfigureConfigFile] to have `config show` take your config file into account.
+func ConfigCommands() CommandOption {
+	return Group("config", "Inspect the configuration of the application",
+		internalCommand(),
+
+		Command(configShowE,
+			"show",
+			"Print the effective configuration merged from flags, environment, config file and defaults",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("format", "table", "Output format, one of table, json")
+			}),
+			NoArgs(),
+		),
+
+		Command(configEnvE,
+			"env",
+			"List the environment variables that can be used to configure the application",
+			NoArgs(),
+		),
+
+		Command(configTemplateE,
+			"template",
+			"Generate a commented config file from all flag usages and defaults",
+			Description(`
+				Generate a config file listing every configurable key along with its usage, the
+				environment variable that overrides it and its default value. The output can be
+				saved and then loaded through the '--config' flag.
+			`),
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("format", "yaml", "Output format, one of yaml, toml")
+			}),
+			NoArgs(),
+		),
+	)
+}
+
+func internalCommand() CommandOption {
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		setCommandAnnotation(cmd, annotationInternalCommand, true)
+	})
+}
+
+// isInternalCommand returns `true` if `cmd` or one of its parents is a command mounted by
+// this library, like [ConfigCommands].
+func isInternalCommand(cmd *cobra.Command) bool {
+	for current := cmd; current != nil; current = current.Parent() {
+		if _, found := getCommandAnnotation(current, annotationInternalCommand); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+func configShowE(cmd *cobra.Command, _ []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	return writeResolvedConfig(cmd, cmd.OutOrStdout(), format)
+}
+
+func configEnvE(cmd *cobra.Command, _ []string) error {
+	type envEntry struct{ env, flag, usage string }
+
+	var entries []envEntry
+	visitReboundFlags(cmd.Root(), func(owner *cobra.Command, flag *pflag.Flag, key string) {
+		if isAliasFlag(flag) {
+			return
+		}
+
+		entries = append(entries, envEntry{envVarName(cmd, key), owner.CommandPath() + " --" + flag.Name, flag.Usage})
+	})
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].env < entries[j].env })
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "ENV\tFLAG\tUSAGE")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", entry.env, entry.flag, entry.usage)
+	}
+
+	return writer.Flush()
+}
+
+func configTemplateE(cmd *cobra.Command, _ []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+
+	root := &templateNode{}
+	visitReboundFlags(cmd.Root(), func(_ *cobra.Command, flag *pflag.Flag, key string) {
+		if flag.Hidden {
+			return
+		}
+
+		root.add(strings.Split(key, "."), templateLeaf{flag: flag, env: envVarName(cmd, key)})
+	})
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "# Configuration file of '%s', generated by '%s'\n", cmd.Root().Name(), cmd.CommandPath())
+	fmt.Fprintln(out, "#")
+	fmt.Fprintln(out, "# Each key can be overridden by the environment variable listed in its comment or by its flag.")
+
+	switch format {
+	case "yaml":
+		fmt.Fprintln(out)
+		root.writeYAML(out, 0)
+	case "toml":
+		root.writeTOML(out, nil)
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of yaml, toml", format)
+	}
+
+	return nil
+}
+
+type templateLeaf struct {
+	name string
+	flag *pflag.Flag
+	env  string
+}
+
+type templateNode struct {
+	leaves   []templateLeaf
+	children map[string]*templateNode
+}
+
+func (n *templateNode) add(path []string, leaf templateLeaf) {
+	if len(path) == 1 {
+		leaf.name = path[0]
+		n.leaves = append(n.leaves, leaf)
+		return
+	}
+
+	if n.children == nil {
+		n.children = map[string]*templateNode{}
+	}
+
+	child, found := n.children[path[0]]
+	if !found {
+		child = &templateNode{}
+		n.children[path[0]] = child
+	}
+
+	child.add(path[1:], leaf)
+}
+
+func (n *templateNode) sortedLeaves() []templateLeaf {
+	sort.Slice(n.leaves, func(i, j int) bool { return n.leaves[i].name < n.leaves[j].name })
+	return n.leaves
+}
+
+func (n *templateNode) sortedChildren() (names []string) {
+	for name := range n.children {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return
+}
+
+func (n *templateNode) writeYAML(out io.Writer, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	for _, leaf := range n.sortedLeaves() {
+		writeTemplateComment(out, indent, leaf)
+		fmt.Fprintf(out, "%s%s: %s\n", indent, leaf.name, templateValue(leaf.flag, ": "))
+	}
+
+	for _, name := range n.sortedChildren() {
+		fmt.Fprintf(out, "%s%s:\n", indent, name)
+		n.children[name].writeYAML(out, depth+1)
+	}
+}
+
+func (n *templateNode) writeTOML(out io.Writer, path []string) {
+	leaves := n.sortedLeaves()
+	if len(leaves) > 0 && len(path) > 0 {
+		fmt.Fprintf(out, "\n[%s]\n", strings.Join(path, "."))
+	}
+
+	for _, leaf := range leaves {
+		writeTemplateComment(out, "", leaf)
+		fmt.Fprintf(out, "%s = %s\n", leaf.name, templateValue(leaf.flag, " = "))
+	}
+
+	for _, name := range n.sortedChildren() {
+		n.children[name].writeTOML(out, append(path, name))
+	}
+}
+
+func writeTemplateComment(out io.Writer, indent string, leaf templateLeaf) {
+	if leaf.flag.Usage != "" {
+		fmt.Fprintf(out, "%s# %s\n", indent, leaf.flag.Usage)
+	}
+
+	fmt.Fprintf(out, "%s# (env %s, flag --%s)\n", indent, leaf.env, leaf.flag.Name)
+}
+
+// templateValue renders the flag's default value in a form valid for both YAML and TOML,
+// `assign` being the key/value separator used for maps.
+func templateValue(flag *pflag.Flag, assign string) string {
+	if IsSecretFlag(flag) {
+		return `""`
+	}
+
+	switch flag.Value.Type() {
+	case "bool", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "count":
+		return flag.DefValue
+
+	case "stringToString", "stringToInt", "stringToInt64":
+		pairs := templateList(flag.DefValue)
+
+		entries := make([]string, 0, len(pairs))
+		for _, pair := range pairs {
+			key, value, _ := strings.Cut(pair, "=")
+			entries = append(entries, strconv.Quote(key)+assign+strconv.Quote(value))
+		}
+
+		if len(entries) == 0 {
+			return "{}"
+		}
+
+		return "{ " + strings.Join(entries, ", ") + " }"
+	}
+
+	if _, isSlice := flag.Value.(pflag.SliceValue); isSlice {
+		elements := templateList(flag.DefValue)
+		for i, element := range elements {
+			elements[i] = strconv.Quote(element)
+		}
+
+		return "[" + strings.Join(elements, ", ") + "]"
+	}
+
+	return strconv.Quote(flag.DefValue)
+}
+
+// templateList splits pflag's textual representation of slices and maps (`[a,b]`).
+func templateList(in string) []string {
+	in = strings.TrimSuffix(strings.TrimPrefix(in, "["), "]")
+	if in == "" {
+		return nil
+	}
+
+	elements, err := csv.NewReader(strings.NewReader(in)).Read()
+	if err != nil {
+		return []string{in}
+	}
+
+	return elements
+}
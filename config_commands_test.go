@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newConfigCommandsTestRoot(out *bytes.Buffer) *cobra.Command {
+	noop := func(cmd *cobra.Command, args []string) error { return nil }
+
+	root := Root("acme", "CLI sample application",
+		PersistentFlags(func(flags *pflag.FlagSet) { flags.String("auth", "", "Auth token") }),
+		Group("tools", "Tools for developers",
+			PersistentFlags(func(flags *pflag.FlagSet) { flags.Bool("dev", false, "Dev mode") }),
+			Command(noop, "read", "Read command",
+				Flags(func(flags *pflag.FlagSet) {
+					flags.Bool("skip-errors", false, "Skip read errors")
+					flags.StringSlice("peers", []string{"a", "b"}, "Peers")
+					flags.StringToInt("weights", map[string]int{"x": 1}, "Weights")
+				}),
+			),
+		),
+		ConfigCommands(),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	root.SetOut(out)
+	return root
+}
+
+func TestConfigCommands_Env(t *testing.T) {
+	out := bytes.NewBuffer(nil)
+	root := newConfigCommandsTestRoot(out)
+
+	root.SetArgs([]string{"config", "env"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, Dedent(`
+		ENV                          FLAG                           USAGE
+		ACME_GLOBAL_AUTH             acme --auth                    Auth token
+		ACME_TOOLS_GLOBAL_DEV        acme tools --dev               Dev mode
+		ACME_TOOLS_READ_PEERS        acme tools read --peers        Peers
+		ACME_TOOLS_READ_SKIP_ERRORS  acme tools read --skip-errors  Skip read errors
+		ACME_TOOLS_READ_WEIGHTS      acme tools read --weights      Weights
+	`), strings.TrimSpace(out.String()))
+}
+
+func TestConfigCommands_Template(t *testing.T) {
+	for _, format := range []string{"yaml", "toml"} {
+		t.Run(format, func(t *testing.T) {
+			out := bytes.NewBuffer(nil)
+			root := newConfigCommandsTestRoot(out)
+
+			root.SetArgs([]string{"config", "template", "--format", format})
+			require.NoError(t, root.Execute())
+
+			assert.Contains(t, out.String(), "# Skip read errors\n")
+			assert.Contains(t, out.String(), "(env ACME_TOOLS_READ_SKIP_ERRORS, flag --skip-errors)")
+
+			parsed := viper.New()
+			parsed.SetConfigType(format)
+			require.NoError(t, parsed.ReadConfig(out), out.String())
+
+			assert.Equal(t, "", parsed.Get("global.auth"))
+			assert.Equal(t, false, parsed.Get("tools.global.dev"))
+			assert.Equal(t, false, parsed.Get("tools.read.skip-errors"))
+			assert.Equal(t, []string{"a", "b"}, parsed.GetStringSlice("tools.read.peers"))
+			assert.Equal(t, map[string]string{"x": "1"}, parsed.GetStringMapString("tools.read.weights"))
+		})
+	}
+}
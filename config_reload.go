@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+)
+
+// configReloadDebounce is the delay waited after the last change event of the config file
+// before reloading it, editors usually emit multiple events when saving a file.
+var configReloadDebounce = 100 * time.Millisecond
+
+// ConfigChange is the change of a single rebound key of the configuration.
+type ConfigChange struct {
+	// Key is the viper key of the rebound flag, like `tools.read.skip-errors`.
+	Key string
+	Old any
+	New any
+}
+
+// ConfigDiff is the list of keys whose value changed on a configuration reload, sorted by key.
+type ConfigDiff []ConfigChange
+
+// Changed returns the change of `key`, if it changed.
+func (d ConfigDiff) Changed(key string) (ConfigChange, bool) {
+	for _, change := range d {
+		if change.Key == key {
+			return change, true
+		}
+	}
+
+	return ConfigChange{}, false
+}
+
+// Keys returns the keys that changed.
+func (d ConfigDiff) Keys() []string {
+	keys := make([]string, len(d))
+	for i, change := range d {
+		keys[i] = change.Key
+	}
+
+	return keys
+}
+
+func (d ConfigDiff) reverse() ConfigDiff {
+	reversed := make(ConfigDiff, len(d))
+	for i, change := range d {
+		reversed[i] = ConfigChange{Key: change.Key, Old: change.New, New: change.Old}
+	}
+
+	return reversed
+}
+
+// ConfigReloader can be implemented by children of the [Application] to be notified of
+// configuration changes, see [Application.OnConfigChange] for the semantics of the returned
+// error. Children are registered automatically when supervised.
+type ConfigReloader interface {
+	ReloadConfig(diff ConfigDiff) error
+}
+
+// OnConfigChange registers `handler` to be called with the rebound keys that changed when
+// the configuration is reloaded, see [Application.WatchConfig]. Handlers are called in
+// registration order, returning an error rejects the reload: the new configuration is
+// discarded and the handlers that already accepted it are called again with the reversed diff.
+//
+// Handlers are called before the new configuration is made visible through viper, so they
+// must pick the new values from the diff instead of reading them back from viper.
+func (a *Application) OnConfigChange(handler func(diff ConfigDiff) error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.configHandlers = append(a.configHandlers, handler)
+}
+
+// WatchConfig reloads the config file loaded by [ConfigureConfigFile] for `cmd` each time it
+// changes on disk or when the process receives SIGHUP while in [Application.WaitForTermination],
+// notifying the handlers registered with [Application.OnConfigChange].
+//
+// The values of rebound flags are recomputed on reload, so flags provided on the command line
+// or through environment variables keep precedence over the config file.
+func (a *Application) WatchConfig(cmd *cobra.Command) error {
+	path, found := ConfigFileUsed(cmd)
+	if !found {
+		return errors.New("no config file loaded, nothing to watch")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("new config watcher: %w", err)
+	}
+
+	// Editors usually replace the file when saving it, so the directory is watched instead
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("watch config file %q: %w", path, err)
+	}
+
+	a.lock.Lock()
+	a.configCmd = cmd
+	a.lock.Unlock()
+
+	// A reload in progress completes before the application terminates
+	stopped := make(chan struct{})
+	a.shutter.OnTerminating(func(_ error) {
+		watcher.Close()
+		<-stopped
+	})
+
+	go func() {
+		defer close(stopped)
+		a.watchConfigFile(watcher, filepath.Clean(path))
+	}()
+
+	return nil
+}
+
+func (a *Application) watchConfigFile(watcher *fsnotify.Watcher, path string) {
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(event.Name) == path && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce = time.After(configReloadDebounce)
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			zlog.Warn("config watcher failed", zap.Error(err))
+
+		case <-debounce:
+			debounce = nil
+			if a.shutter.IsTerminating() {
+				return
+			}
+
+			if err := a.ReloadConfig(); err != nil {
+				zlog.Warn("config reload failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// ReloadConfig reloads the config file watched through [Application.WatchConfig] and notifies
+// the handlers registered with [Application.OnConfigChange] of the rebound keys that changed.
+//
+// The new configuration is resolved aside and swapped into the command's viper instance only
+// once all handlers accepted it, while holding the lock taken by [ReadViper].
+func (a *Application) ReloadConfig() error {
+	a.reloadLock.Lock()
+	defer a.reloadLock.Unlock()
+
+	a.lock.Lock()
+	cmd := a.configCmd
+	handlers := append([]func(ConfigDiff) error{}, a.configHandlers...)
+	a.lock.Unlock()
+
+	if cmd == nil {
+		return errors.New("config is not watched, call WatchConfig first")
+	}
+
+	path, _ := ConfigFileUsed(cmd)
+	root := cmd.Root()
+	v := ViperFor(cmd)
+
+	settings, err := readConfigFile(root, path)
+	if err != nil {
+		return ConfigError(err)
+	}
+
+	candidate, err := candidateViper(root, v, settings)
+	if err != nil {
+		return ConfigError(err)
+	}
+
+	// Reloads are serialized and are the only writers of the viper instance once the command
+	// runs, reading it without the lock is safe here
+	diff := diffReboundValues(reboundValues(root, v), reboundValues(root, candidate))
+	if len(diff) == 0 {
+		zlog.Debug("config reloaded, no rebound key changed", zap.String("path", path))
+		return nil
+	}
+
+	for i, handler := range handlers {
+		if err := handler(diff); err != nil {
+			zlog.Warn("config reload rejected, keeping previous config", zap.Error(err))
+
+			reverted := diff.reverse()
+			for _, accepted := range handlers[:i] {
+				if err := accepted(reverted); err != nil {
+					zlog.Error("config change handler failed to restore previous config", zap.Error(err))
+				}
+			}
+
+			return ConfigError(fmt.Errorf("config reload rejected: %w", err))
+		}
+	}
+
+	lock := viperLock(v)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := replaceConfig(v, settings); err != nil {
+		return ConfigError(fmt.Errorf("swap reloaded config: %w", err))
+	}
+	setCommandAnnotation(root, annotationConfigSettings, settings)
+
+	zlog.Info("config reloaded", zap.String("path", path), zap.Strings("changed", diff.Keys()))
+	return nil
+}
+
+func (a *Application) isConfigWatched() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.configCmd != nil
+}
+
+// candidateViper returns a viper instance resolving the rebound keys like `v` would once its
+// config layer is replaced by `settings`. Values not coming from the config file, or resolved
+// by this library (see [Secret] and [FlagAlias]), are carried over as is.
+func candidateViper(root *cobra.Command, v *viper.Viper, settings map[string]any) (*viper.Viper, error) {
+	candidate := viper.New()
+	if err := replaceConfig(candidate, settings); err != nil {
+		return nil, err
+	}
+
+	visitReboundFlags(root, func(cmd *cobra.Command, flag *pflag.Flag, key string) {
+		candidate.BindPFlag(key, flag)
+		candidate.BindPFlag(dashKey(key), flag)
+
+		source, _ := FlagValueSource(cmd, flag)
+		if _, resolved := flag.Annotations[resolvedSourceAnnotation]; resolved || (source != ValueSourceConfig && source != ValueSourceDefault) {
+			setReboundValue(candidate, key, v.Get(key))
+		}
+	})
+
+	return candidate, nil
+}
+
+func reboundValues(root *cobra.Command, v *viper.Viper) map[string]any {
+	values := map[string]any{}
+	visitReboundFlags(root, func(_ *cobra.Command, flag *pflag.Flag, key string) {
+		if !isAliasFlag(flag) {
+			values[key] = v.Get(key)
+		}
+	})
+
+	return values
+}
+
+func diffReboundValues(before, after map[string]any) (diff ConfigDiff) {
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			diff = append(diff, ConfigChange{Key: key, Old: before[key], New: value})
+		}
+	}
+
+	sort.Slice(diff, func(i, j int) bool { return diff[i].Key < diff[j].Key })
+	return diff
+}
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/shutter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplication_ReloadConfig(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		reject       bool
+		expectedDiff ConfigDiff
+		expectedRate int
+		expectedErr  string
+	}{
+		{
+			"accepted",
+			nil,
+			false,
+			ConfigDiff{{"read.log-level", "info", "debug"}, {"read.rate", 10, 20}},
+			20,
+			"",
+		},
+		{
+			"rejected",
+			nil,
+			true,
+			ConfigDiff{{"read.log-level", "info", "debug"}, {"read.rate", 10, 20}},
+			10,
+			"config reload rejected: child \"ingester\": rate too high",
+		},
+		{
+			"flag keeps precedence",
+			[]string{"--rate", "5"},
+			false,
+			ConfigDiff{{"read.log-level", "info", "debug"}},
+			5,
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+
+			path := filepath.Join(t.TempDir(), "acme.yaml")
+			WriteFile(path, "%s", "read:\n  rate: 10\n  log-level: info\n")
+
+			root, cmd := configReloadRoot(t, path, tt.args...)
+
+			app := NewApplication(context.Background())
+			defer app.shutter.Shutdown(nil)
+
+			var seen, reverted []ConfigDiff
+			var rateDuringReload int
+			app.OnConfigChange(func(diff ConfigDiff) error {
+				if len(seen) > 0 {
+					reverted = append(reverted, diff)
+				} else {
+					seen = append(seen, diff)
+					rateDuringReload = ViperFor(root).GetInt("read.rate")
+				}
+
+				return nil
+			})
+			app.SuperviseNamed("ingester", &reloadingChild{Shutter: shutter.New(), reject: tt.reject})
+
+			require.NoError(t, app.WatchConfig(cmd))
+
+			rateBeforeReload := ViperFor(root).GetInt("read.rate")
+			WriteFile(path, "%s", "read:\n  rate: 20\n  log-level: debug\n")
+			err := app.ReloadConfig()
+
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Empty(t, reverted)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+				assert.Equal(t, ExitCodeConfig, ExitCode(err))
+				assert.Equal(t, []ConfigDiff{tt.expectedDiff.reverse()}, reverted)
+			}
+
+			require.Len(t, seen, 1)
+			assert.Equal(t, tt.expectedDiff, seen[0])
+			assert.Equal(t, rateBeforeReload, rateDuringReload, "the new config must not be visible before being accepted")
+			assert.Equal(t, tt.expectedRate, ViperFor(root).GetInt("read.rate"))
+			assert.Equal(t, tt.expectedRate, ViperFor(root).GetInt("read-rate"))
+		})
+	}
+}
+
+func TestApplication_WatchConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	WriteFile(path, "%s", "read:\n  rate: 10\n")
+
+	_, cmd := configReloadRoot(t, path)
+
+	app := NewApplication(context.Background())
+	defer app.shutter.Shutdown(nil)
+
+	var lock sync.Mutex
+	var diffs []ConfigDiff
+	app.OnConfigChange(func(diff ConfigDiff) error {
+		lock.Lock()
+		defer lock.Unlock()
+
+		diffs = append(diffs, diff)
+		return nil
+	})
+
+	require.NoError(t, app.WatchConfig(cmd))
+	WriteFile(path, "%s", "read:\n  rate: 30\n")
+
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return len(diffs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	change, found := diffs[0].Changed("read.rate")
+	require.True(t, found)
+	assert.Equal(t, 30, change.New)
+}
+
+func TestApplication_ReloadConfig_ConcurrentReads(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	WriteFile(path, "%s", "read:\n  rate: 10\n")
+
+	_, cmd := configReloadRoot(t, path)
+
+	app := NewApplication(context.Background())
+	defer app.shutter.Shutdown(nil)
+	require.NoError(t, app.WatchConfig(cmd))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			ReadViper(cmd, func(v *viper.Viper) { v.GetInt("read.rate") })
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		WriteFile(path, "read:\n  rate: %d\n", 20+i)
+		require.NoError(t, app.ReloadConfig())
+	}
+	<-done
+
+	ReadViper(cmd, func(v *viper.Viper) { assert.Equal(t, 29, v.GetInt("read.rate")) })
+}
+
+func TestApplication_WatchConfig_NoConfigFile(t *testing.T) {
+	app := NewApplication(context.Background())
+	defer app.shutter.Shutdown(nil)
+
+	assert.EqualError(t, app.WatchConfig(&cobra.Command{}), "no config file loaded, nothing to watch")
+	assert.EqualError(t, app.ReloadConfig(), "config is not watched, call WatchConfig first")
+}
+
+func configReloadRoot(t *testing.T, path string, args ...string) (root, executed *cobra.Command) {
+	t.Helper()
+
+	root = Root("acme", "CLI sample application",
+		Command(func(cmd *cobra.Command, _ []string) error {
+			executed = cmd
+			return nil
+		}, "read", "Read command",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.Int("rate", 0, "Read rate")
+				flags.String("log-level", "", "Log level")
+			}),
+		),
+		ConfigureViper("ACME"),
+		ConfigureConfigFile("acme"),
+	)
+
+	root.SetArgs(append([]string{"read", "--config", path}, args...))
+	require.NoError(t, root.Execute())
+
+	return root, executed
+}
+
+type reloadingChild struct {
+	*shutter.Shutter
+	reject bool
+}
+
+func (c *reloadingChild) ReloadConfig(diff ConfigDiff) error {
+	if c.reject {
+		return errors.New("rate too high")
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			"yaml nested",
+			"acme.yaml",
+			`
+				global:
+				  auth: "token"
+				tools:
+				  global:
+				    dev: true
+				  read:
+				    skip-errors: true
+			`,
+		},
+		{
+			"yaml flat",
+			"acme.yaml",
+			`
+				global-auth: "token"
+				tools-global-dev: true
+				tools-read-skip-errors: true
+			`,
+		},
+		{
+			"toml",
+			"acme.toml",
+			`
+				[global]
+				auth = "token"
+
+				[tools.global]
+				dev = true
+
+				[tools.read]
+				skip-errors = true
+			`,
+		},
+		{
+			"json mixed",
+			"acme.json",
+			`{"global": {"auth": "token"}, "tools-global-dev": true, "tools": {"read": {"skip-errors": true}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+
+			path := filepath.Join(t.TempDir(), tt.file)
+			WriteFile(path, "%s", Dedent(tt.content))
+
+			var auth string
+			var dev, skipErrors bool
+
+			root := Root("acme", "CLI sample application",
+				PersistentFlags(func(flags *pflag.FlagSet) { flags.String("auth", "", "Auth token") }),
+				Group("tools", "Tools for developers",
+					PersistentFlags(func(flags *pflag.FlagSet) { flags.Bool("dev", false, "Dev mode") }),
+					Command(func(cmd *cobra.Command, args []string) error {
+						auth = viper.GetString("global.auth")
+						dev = viper.GetBool("tools.global.dev")
+						skipErrors = viper.GetBool("tools.read.skip-errors")
+
+						assert.Equal(t, auth, viper.GetString("global-auth"))
+						assert.Equal(t, dev, viper.GetBool("tools-global-dev"))
+						assert.Equal(t, skipErrors, viper.GetBool("tools-read-skip-errors"))
+						return nil
+					}, "read", "Read command",
+						Flags(func(flags *pflag.FlagSet) { flags.Bool("skip-errors", false, "Skip read errors") }),
+					),
+				),
+				ConfigureViper("ACME"),
+				ConfigureConfigFile("acme"),
+			)
+
+			root.SetArgs([]string{"tools", "read", "--config", path})
+			require.NoError(t, root.Execute())
+
+			assert.Equal(t, "token", auth)
+			assert.True(t, dev)
+			assert.True(t, skipErrors)
+
+			used, found := ConfigFileUsed(root)
+			assert.True(t, found)
+			assert.Equal(t, path, used)
+		})
+	}
+}
+
+func TestConfigureConfigFile_MissingExplicitFile(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+		ConfigureViper("ACME"),
+		ConfigureConfigFile("acme"),
+	)
+
+	root.SetArgs([]string{"--config", filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.ErrorContains(t, root.Execute(), "does not exist")
+}
@@ -0,0 +1,466 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// DocsFormat is the output format of [GenerateDocs].
+type DocsFormat string
+
+const (
+	// DocsFormatMan generates one man page (section 1) per command
+	DocsFormatMan DocsFormat = "man"
+	// DocsFormatMarkdown generates one Markdown page per command, linked together
+	DocsFormatMarkdown DocsFormat = "markdown"
+	// DocsFormatJSON generates a single JSON file describing the whole command tree, it's not a
+	// JSON Schema, see [DocsFormatJSONSchema] for that
+	DocsFormatJSON DocsFormat = "json"
+	// DocsFormatJSONSchema generates the JSON Schema of the config file, each rebound key being
+	// described with its type, default value and environment variable (`x-env` keyword)
+	DocsFormatJSONSchema DocsFormat = "json-schema"
+)
+
+var DocsFormats = []DocsFormat{DocsFormatMan, DocsFormatMarkdown, DocsFormatJSON, DocsFormatJSONSchema}
+
+// GenerateDocs writes the reference documentation of every available command of the tree
+// starting at `root` in `outDir`, created if it doesn't exist. Hidden and deprecated commands
+// as well as hidden flags are skipped.
+//
+// The documentation of each command contains its description, examples and flags, flags
+// rebound by [ConfigureViper] list their viper key and environment variable. The
+// [DocsFormatJSONSchema] format documents the config file instead of the commands.
+func GenerateDocs(root *cobra.Command, format DocsFormat, outDir string) error {
+	if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	doc := newCommandDoc(root)
+
+	switch format {
+	case DocsFormatMan:
+		return doc.visit(func(doc *commandDoc) error {
+			return writeDocFile(filepath.Join(outDir, doc.fileName("-")+".1"), doc.writeMan)
+		})
+	case DocsFormatMarkdown:
+		return doc.visit(func(doc *commandDoc) error {
+			return writeDocFile(filepath.Join(outDir, doc.fileName("_")+".md"), doc.writeMarkdown)
+		})
+	case DocsFormatJSON:
+		return writeDocFile(filepath.Join(outDir, root.Name()+".json"), func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(doc)
+		})
+	case DocsFormatJSONSchema:
+		return writeDocFile(filepath.Join(outDir, root.Name()+".schema.json"), func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(configJSONSchema(root))
+		})
+	}
+
+	return fmt.Errorf("unsupported docs format %q", format)
+}
+
+// ConfigureDocs mounts a hidden `docs <format>` command on the [cobra.Command] (usually the
+// root) generating the reference documentation with [GenerateDocs].
+func ConfigureDocs() CommandOption {
+	formats := make([]string, len(DocsFormats))
+	for i, format := range DocsFormats {
+		formats[i] = string(format)
+	}
+
+	return Command(docsE,
+		"docs",
+		"Generate the reference documentation of the application",
+		internalCommand(),
+		CommandOptionFunc(func(cmd *cobra.Command) { cmd.Hidden = true }),
+		Flags(func(flags *pflag.FlagSet) {
+			flags.String("output-dir", "docs", "Directory where the documentation is written")
+		}),
+		Arg[string]("format", "The documentation format, one of "+strings.Join(formats, ", ")),
+		CompleteArgs(CompleteValues(formats...)),
+	)
+}
+
+func docsE(cmd *cobra.Command, _ []string) error {
+	outDir, err := cmd.Flags().GetString("output-dir")
+	if err != nil {
+		return err
+	}
+
+	return GenerateDocs(cmd.Root(), DocsFormat(MustGetArg[string](cmd, "format")), outDir)
+}
+
+type commandDoc struct {
+	Path        string        `json:"path"`
+	Usage       string        `json:"usage"`
+	Short       string        `json:"short"`
+	Description string        `json:"description,omitempty"`
+	Example     string        `json:"example,omitempty"`
+	Arguments   []argDoc      `json:"arguments,omitempty"`
+	Flags       []flagDoc     `json:"flags,omitempty"`
+	Inherited   []flagDoc     `json:"inherited_flags,omitempty"`
+	Commands    []*commandDoc `json:"commands,omitempty"`
+
+	parent *commandDoc
+}
+
+type argDoc struct {
+	Name     string `json:"name"`
+	Usage    string `json:"usage"`
+	Optional bool   `json:"optional"`
+	Variadic bool   `json:"variadic"`
+}
+
+type flagDoc struct {
+	Name      string `json:"name"`
+	Shorthand string `json:"shorthand,omitempty"`
+	Type      string `json:"type"`
+	Default   string `json:"default"`
+	Usage     string `json:"usage"`
+	ViperKey  string `json:"viper_key,omitempty"`
+	EnvVar    string `json:"env,omitempty"`
+}
+
+func newCommandDoc(cmd *cobra.Command) *commandDoc {
+	doc := &commandDoc{
+		Path:        cmd.CommandPath(),
+		Usage:       cmd.UseLine(),
+		Short:       cmd.Short,
+		Description: cmd.Long,
+		Example:     Dedent(cmd.Example),
+		Flags:       newFlagDocs(cmd, cmd.NonInheritedFlags()),
+		Inherited:   newFlagDocs(cmd, cmd.InheritedFlags()),
+	}
+
+	for _, def := range argDefinitions(cmd) {
+		doc.Arguments = append(doc.Arguments, argDoc{def.name, def.usage, def.optional, def.variadic})
+	}
+
+	for _, child := range cmd.Commands() {
+		if !child.IsAvailableCommand() || child.IsAdditionalHelpTopicCommand() {
+			continue
+		}
+
+		childDoc := newCommandDoc(child)
+		childDoc.parent = doc
+		doc.Commands = append(doc.Commands, childDoc)
+	}
+
+	return doc
+}
+
+func newFlagDocs(cmd *cobra.Command, flags *pflag.FlagSet) (out []flagDoc) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		doc := flagDoc{
+			Name:      flag.Name,
+			Shorthand: flag.Shorthand,
+			Type:      flag.Value.Type(),
+			Default:   flag.DefValue,
+			Usage:     flag.Usage,
+		}
+
+		if key, found := reboundKey(flag); found {
+			doc.ViperKey = key
+			doc.EnvVar = envVarName(cmd, key)
+		}
+
+		out = append(out, doc)
+	})
+
+	return
+}
+
+func (d *commandDoc) visit(onDoc func(doc *commandDoc) error) error {
+	if err := onDoc(d); err != nil {
+		return err
+	}
+
+	for _, child := range d.Commands {
+		if err := child.visit(onDoc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *commandDoc) fileName(separator string) string {
+	return strings.ReplaceAll(d.Path, " ", separator)
+}
+
+func (d *commandDoc) writeMarkdown(w io.Writer) error {
+	fmt.Fprintf(w, "## %s\n\n%s\n\n", d.Path, d.Short)
+
+	if d.Description != "" {
+		fmt.Fprintf(w, "### Synopsis\n\n%s\n\n", d.Description)
+	}
+
+	fmt.Fprintf(w, "```\n%s\n```\n\n", d.Usage)
+
+	if d.Example != "" {
+		fmt.Fprintf(w, "### Examples\n\n```\n%s\n```\n\n", d.Example)
+	}
+
+	writeMarkdownFlags(w, "Options", d.Flags)
+	writeMarkdownFlags(w, "Options inherited from parent commands", d.Inherited)
+
+	if d.parent != nil || len(d.Commands) > 0 {
+		fmt.Fprint(w, "### See also\n\n")
+		if d.parent != nil {
+			fmt.Fprintf(w, "* [%s](%s.md) - %s\n", d.parent.Path, d.parent.fileName("_"), d.parent.Short)
+		}
+
+		for _, child := range d.Commands {
+			fmt.Fprintf(w, "* [%s](%s.md) - %s\n", child.Path, child.fileName("_"), child.Short)
+		}
+	}
+
+	return nil
+}
+
+func writeMarkdownFlags(w io.Writer, title string, flags []flagDoc) {
+	if len(flags) == 0 {
+		return
+	}
+
+	escape := strings.NewReplacer("|", `\|`, "\n", "<br>").Replace
+
+	fmt.Fprintf(w, "### %s\n\n", title)
+	fmt.Fprint(w, "| Flag | Type | Default | Environment variable | Config key | Description |\n")
+	fmt.Fprint(w, "|------|------|---------|----------------------|------------|-------------|\n")
+	for _, flag := range flags {
+		name := "`--" + flag.Name + "`"
+		if flag.Shorthand != "" {
+			name = "`-" + flag.Shorthand + "`, " + name
+		}
+
+		fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s |\n", name, flag.Type, markdownCode(flag.Default), markdownCode(flag.EnvVar), markdownCode(flag.ViperKey), escape(flag.Usage))
+	}
+
+	fmt.Fprintln(w)
+}
+
+func markdownCode(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	return "`" + value + "`"
+}
+
+func (d *commandDoc) writeMan(w io.Writer) error {
+	rootName := strings.SplitN(d.Path, " ", 2)[0]
+
+	fmt.Fprintf(w, ".TH \"%s\" \"1\" \"\" \"%s\" \"\"\n", strings.ToUpper(d.fileName("-")), rootName)
+	fmt.Fprintf(w, ".SH NAME\n%s \\- %s\n", d.fileName("-"), manEscape(d.Short))
+	fmt.Fprintf(w, ".SH SYNOPSIS\n\\fB%s\\fP\n", manEscape(d.Usage))
+
+	if d.Description != "" {
+		fmt.Fprintf(w, ".SH DESCRIPTION\n.nf\n%s\n.fi\n", manEscape(d.Description))
+	}
+
+	writeManFlags(w, "OPTIONS", d.Flags)
+	writeManFlags(w, "OPTIONS INHERITED FROM PARENT COMMANDS", d.Inherited)
+
+	if d.Example != "" {
+		fmt.Fprintf(w, ".SH EXAMPLE\n.PP\n.RS\n.nf\n%s\n.fi\n.RE\n", manEscape(d.Example))
+	}
+
+	if d.parent != nil || len(d.Commands) > 0 {
+		var related []string
+		if d.parent != nil {
+			related = append(related, fmt.Sprintf("\\fB%s\\fP(1)", d.parent.fileName("-")))
+		}
+
+		for _, child := range d.Commands {
+			related = append(related, fmt.Sprintf("\\fB%s\\fP(1)", child.fileName("-")))
+		}
+
+		fmt.Fprintf(w, ".SH SEE ALSO\n%s\n", strings.Join(related, ", "))
+	}
+
+	return nil
+}
+
+func writeManFlags(w io.Writer, title string, flags []flagDoc) {
+	if len(flags) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, ".SH %s\n", title)
+	for _, flag := range flags {
+		name := "\\fB\\-\\-" + manEscape(flag.Name) + "\\fP"
+		if flag.Shorthand != "" {
+			name = "\\fB\\-" + manEscape(flag.Shorthand) + "\\fP, " + name
+		}
+
+		fmt.Fprintf(w, ".TP\n%s %s\n%s", name, flag.Type, manEscape(flag.Usage))
+		if flag.Default != "" {
+			fmt.Fprintf(w, " (default %s)", manEscape(flag.Default))
+		}
+		fmt.Fprintln(w)
+
+		if flag.EnvVar != "" {
+			fmt.Fprintf(w, ".br\nEnvironment variable \\fB%s\\fP, config key \\fB%s\\fP\n", manEscape(flag.EnvVar), manEscape(flag.ViperKey))
+		}
+	}
+}
+
+var manEscaper = strings.NewReplacer(`\`, `\e`, "-", `\-`)
+
+// manEscape escapes roff special characters and protects lines starting with a control character.
+func manEscape(in string) string {
+	lines := strings.Split(manEscaper.Replace(in), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// jsonSchemaDraft is the JSON Schema dialect of the schema generated by [DocsFormatJSONSchema].
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// configJSONSchema returns the JSON Schema of the config file of `root`, the nested keys of the
+// rebound flags (see [ConfigureViper]) being described as nested objects.
+func configJSONSchema(root *cobra.Command) map[string]any {
+	tree := &templateNode{}
+	visitReboundFlags(root, func(cmd *cobra.Command, flag *pflag.Flag, key string) {
+		if flag.Hidden {
+			return
+		}
+
+		tree.add(strings.Split(key, "."), templateLeaf{flag: flag, env: envVarName(cmd, key)})
+	})
+
+	schema := tree.jsonSchema()
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = fmt.Sprintf("Configuration file of '%s'", root.Name())
+	return schema
+}
+
+func (n *templateNode) jsonSchema() map[string]any {
+	properties := map[string]any{}
+	for _, leaf := range n.leaves {
+		properties[leaf.name] = flagJSONSchema(leaf)
+	}
+
+	for name, child := range n.children {
+		properties[name] = child.jsonSchema()
+	}
+
+	return map[string]any{"type": "object", "properties": properties}
+}
+
+func flagJSONSchema(leaf templateLeaf) map[string]any {
+	flag := leaf.flag
+	schema := map[string]any{"x-env": leaf.env}
+	if flag.Usage != "" {
+		schema["description"] = flag.Usage
+	}
+
+	flagType := flag.Value.Type()
+	switch {
+	case strings.HasPrefix(flagType, "stringTo"):
+		valueType := jsonSchemaType(strings.ToLower(strings.TrimPrefix(flagType, "stringTo")))
+		schema["type"] = "object"
+		schema["additionalProperties"] = map[string]any{"type": valueType}
+
+		defaults := map[string]any{}
+		for _, pair := range templateList(flag.DefValue) {
+			key, raw, _ := strings.Cut(pair, "=")
+			if value, ok := jsonSchemaValue(valueType, raw); ok {
+				defaults[key] = value
+			}
+		}
+		schema["default"] = defaults
+
+	case isSliceFlag(flag):
+		itemType := jsonSchemaType(strings.TrimSuffix(flagType, "Slice"))
+		schema["type"] = "array"
+		schema["items"] = map[string]any{"type": itemType}
+
+		defaults := []any{}
+		for _, raw := range templateList(flag.DefValue) {
+			if value, ok := jsonSchemaValue(itemType, raw); ok {
+				defaults = append(defaults, value)
+			}
+		}
+		schema["default"] = defaults
+
+	default:
+		schema["type"] = jsonSchemaType(flagType)
+		if value, ok := jsonSchemaValue(schema["type"].(string), flag.DefValue); ok && !IsSecretFlag(flag) {
+			schema["default"] = value
+		}
+	}
+
+	return schema
+}
+
+func isSliceFlag(flag *pflag.Flag) bool {
+	_, isSlice := flag.Value.(pflag.SliceValue)
+	return isSlice
+}
+
+// jsonSchemaType maps a pflag type (or the element type of a slice or map flag) to its JSON
+// Schema type, textual flags like durations or IPs being strings.
+func jsonSchemaType(flagType string) string {
+	switch flagType {
+	case "bool":
+		return "boolean"
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "count":
+		return "integer"
+	case "float32", "float64":
+		return "number"
+	}
+
+	return "string"
+}
+
+func jsonSchemaValue(schemaType string, raw string) (any, bool) {
+	switch schemaType {
+	case "boolean":
+		value, err := strconv.ParseBool(raw)
+		return value, err == nil
+	case "integer":
+		value, err := strconv.ParseInt(raw, 10, 64)
+		return value, err == nil
+	case "number":
+		value, err := strconv.ParseFloat(raw, 64)
+		return value, err == nil
+	}
+
+	return raw, true
+}
+
+func writeDocFile(path string, write func(w io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %q: %w", path, err)
+	}
+
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("write %q: %w", path, err)
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocsTestRoot(opts ...CommandOption) *cobra.Command {
+	return Root("acme", "CLI sample application", append([]CommandOption{
+		Group("tools", "Tooling commands",
+			Command(func(cmd *cobra.Command, args []string) error { return nil },
+				"deploy",
+				"Deploy the application",
+				Description(`
+					Deploys the application to the given target.
+				`),
+				Example(`
+					acme tools deploy staging
+				`),
+				Flags(func(flags *pflag.FlagSet) {
+					flags.StringP("network", "n", "mainnet", "Network to deploy to")
+				}),
+				Arg[string]("target", "Deployment target"),
+			),
+		),
+		Command(func(cmd *cobra.Command, args []string) error { return nil }, "secret", "Hidden command",
+			CommandOptionFunc(func(cmd *cobra.Command) { cmd.Hidden = true }),
+		),
+		PersistentFlags(func(flags *pflag.FlagSet) {
+			flags.String("log-format", "text", "Logging format")
+		}),
+		ConfigureDocs(),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	}, opts...)...)
+}
+
+func TestGenerateDocs_Markdown(t *testing.T) {
+	outDir := t.TempDir()
+	require.NoError(t, GenerateDocs(newDocsTestRoot(), DocsFormatMarkdown, outDir))
+
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	assert.Equal(t, []string{"acme.md", "acme_tools.md", "acme_tools_deploy.md"}, files)
+
+	content, err := os.ReadFile(filepath.Join(outDir, "acme_tools_deploy.md"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Dedent("\n"+
+		"## acme tools deploy\n"+
+		"\n"+
+		"Deploy the application\n"+
+		"\n"+
+		"### Synopsis\n"+
+		"\n"+
+		"Deploys the application to the given target.\n"+
+		"\n"+
+		"Arguments:\n"+
+		"  <target>   Deployment target\n"+
+		"\n"+
+		"```\n"+
+		"acme tools deploy <target> [flags]\n"+
+		"```\n"+
+		"\n"+
+		"### Examples\n"+
+		"\n"+
+		"```\n"+
+		"acme tools deploy staging\n"+
+		"```\n"+
+		"\n"+
+		"### Options\n"+
+		"\n"+
+		"| Flag | Type | Default | Environment variable | Config key | Description |\n"+
+		"|------|------|---------|----------------------|------------|-------------|\n"+
+		"| `-n`, `--network` | string | `mainnet` | `ACME_TOOLS_DEPLOY_NETWORK` | `tools.deploy.network` | Network to deploy to |\n"+
+		"\n"+
+		"### Options inherited from parent commands\n"+
+		"\n"+
+		"| Flag | Type | Default | Environment variable | Config key | Description |\n"+
+		"|------|------|---------|----------------------|------------|-------------|\n"+
+		"| `--log-format` | string | `text` | `ACME_GLOBAL_LOG_FORMAT` | `global.log-format` | Logging format |\n"+
+		"\n"+
+		"### See also\n"+
+		"\n"+
+		"* [acme tools](acme_tools.md) - Tooling commands\n",
+	), Dedent(string(content)))
+}
+
+func TestGenerateDocs_Man(t *testing.T) {
+	outDir := t.TempDir()
+	require.NoError(t, GenerateDocs(newDocsTestRoot(), DocsFormatMan, outDir))
+
+	content, err := os.ReadFile(filepath.Join(outDir, "acme-tools-deploy.1"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), `.TH "ACME-TOOLS-DEPLOY" "1" "" "acme" ""`)
+	assert.Contains(t, string(content), ".SH NAME\nacme-tools-deploy \\- Deploy the application\n")
+	assert.Contains(t, string(content), ".TP\n\\fB\\-n\\fP, \\fB\\-\\-network\\fP string\nNetwork to deploy to (default mainnet)\n"+
+		".br\nEnvironment variable \\fBACME_TOOLS_DEPLOY_NETWORK\\fP, config key \\fBtools.deploy.network\\fP\n")
+	assert.Contains(t, string(content), ".SH SEE ALSO\n\\fBacme-tools\\fP(1)\n")
+}
+
+func TestGenerateDocs_JSON(t *testing.T) {
+	outDir := t.TempDir()
+
+	root := newDocsTestRoot()
+	root.SetArgs([]string{"docs", "json", "--output-dir", outDir})
+	require.NoError(t, root.Execute())
+
+	content, err := os.ReadFile(filepath.Join(outDir, "acme.json"))
+	require.NoError(t, err)
+
+	var doc commandDoc
+	require.NoError(t, json.Unmarshal(content, &doc))
+
+	require.Len(t, doc.Commands, 1)
+	require.Len(t, doc.Commands[0].Commands, 1)
+
+	deploy := doc.Commands[0].Commands[0]
+	assert.Equal(t, "acme tools deploy", deploy.Path)
+	assert.Equal(t, []argDoc{{Name: "target", Usage: "Deployment target"}}, deploy.Arguments)
+	assert.Equal(t, []flagDoc{{
+		Name:      "network",
+		Shorthand: "n",
+		Type:      "string",
+		Default:   "mainnet",
+		Usage:     "Network to deploy to",
+		ViperKey:  "tools.deploy.network",
+		EnvVar:    "ACME_TOOLS_DEPLOY_NETWORK",
+	}}, deploy.Flags)
+}
+
+func TestGenerateDocs_JSONSchema(t *testing.T) {
+	outDir := t.TempDir()
+
+	root := newDocsTestRoot(Command(func(cmd *cobra.Command, args []string) error { return nil }, "read", "Read command",
+		Flags(func(flags *pflag.FlagSet) {
+			flags.Int("rate", 10, "Read rate")
+			flags.StringSlice("tags", []string{"a", "b"}, "Tags")
+			flags.StringToInt("weights", nil, "Weights")
+		}),
+	))
+
+	root.SetArgs([]string{"docs", "json-schema", "--output-dir", outDir})
+	require.NoError(t, root.Execute())
+
+	content, err := os.ReadFile(filepath.Join(outDir, "acme.schema.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Configuration file of 'acme'",
+		"type": "object",
+		"properties": {
+			"global": {"type": "object", "properties": {
+				"log-format": {"type": "string", "default": "text", "description": "Logging format", "x-env": "ACME_GLOBAL_LOG_FORMAT"}
+			}},
+			"read": {"type": "object", "properties": {
+				"rate": {"type": "integer", "default": 10, "description": "Read rate", "x-env": "ACME_READ_RATE"},
+				"tags": {"type": "array", "items": {"type": "string"}, "default": ["a", "b"], "description": "Tags", "x-env": "ACME_READ_TAGS"},
+				"weights": {"type": "object", "additionalProperties": {"type": "integer"}, "default": {}, "description": "Weights", "x-env": "ACME_READ_WEIGHTS"}
+			}},
+			"tools": {"type": "object", "properties": {
+				"deploy": {"type": "object", "properties": {
+					"network": {"type": "string", "default": "mainnet", "description": "Network to deploy to", "x-env": "ACME_TOOLS_DEPLOY_NETWORK"}
+				}}
+			}}
+		}
+	}`, string(content))
+}
+
+func TestGenerateDocs_UnsupportedFormat(t *testing.T) {
+	assert.EqualError(t, GenerateDocs(newDocsTestRoot(), "html", t.TempDir()), `unsupported docs format "html"`)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Exit codes used by this library, the non-generic ones follow `sysexits.h` conventions so
+// scripts can tell apart bad input from runtime failures.
+const (
+	ExitCodeOK = 0
+	// ExitCodeFailure is used for any error that is not categorized
+	ExitCodeFailure = 1
+	// ExitCodeUsage is used for invalid command line, see [UsageError]
+	ExitCodeUsage = 2
+	// ExitCodePanic is used when the process recovered from a panic, see [PanicError]
+	ExitCodePanic = 70
+	// ExitCodeNotFound is used when an input (file, resource) does not exist, see [NotFoundError]
+	ExitCodeNotFound = 66
+	// ExitCodeConfig is used when the configuration is invalid, see [ConfigError]
+	ExitCodeConfig = 78
+	// ExitCodeInterrupted is used when the process is interrupted by the user, see [InterruptedError]
+	ExitCodeInterrupted = 130
+)
+
+// ExitError is an error carrying the exit code the process should terminate with when it
+// is returned from a command's handler executed by [Run].
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error { return e.Err }
+
+// ConfigError wraps `err` into an [ExitError] with code [ExitCodeConfig].
+func ConfigError(err error) error {
+	return &ExitError{Code: ExitCodeConfig, Err: err}
+}
+
+// NotFoundError wraps `err` into an [ExitError] with code [ExitCodeNotFound].
+func NotFoundError(err error) error {
+	return &ExitError{Code: ExitCodeNotFound, Err: err}
+}
+
+// InterruptedError wraps `err` into an [ExitError] with code [ExitCodeInterrupted].
+func InterruptedError(err error) error {
+	return &ExitError{Code: ExitCodeInterrupted, Err: err}
+}
+
+// UsageError is returned when the command line is invalid, like a flag failing one of the
+// declared validations. It leads to exit code [ExitCodeUsage] and [Run] prints the command's
+// usage along the error.
+type UsageError struct {
+	Err error
+}
+
+func (e *UsageError) Error() string { return e.Err.Error() }
+func (e *UsageError) Unwrap() error { return e.Err }
+
+func usageErrorf(format string, args ...any) error {
+	return &UsageError{Err: fmt.Errorf(format, args...)}
+}
+
+// cobraUsageErrorPrefixes are the prefixes of the command line errors cobra returns untyped,
+// the flag and positional argument errors being already turned into [UsageError].
+var cobraUsageErrorPrefixes = []string{"unknown command ", "required flag(s) "}
+
+// cobraUsageError wraps into a [UsageError] the errors produced by cobra for an invalid command
+// line, other errors are returned as-is.
+func cobraUsageError(err error) error {
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return err
+	}
+
+	for _, prefix := range cobraUsageErrorPrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return &UsageError{Err: err}
+		}
+	}
+
+	return err
+}
+
+// ExitCode returns the exit code matching `err`: [ExitCodeOK] if nil, the code of the first
+// [ExitError] found in the chain, [ExitCodeUsage] for a [UsageError], [ExitCodePanic] for
+// a [PanicError] and [ExitCodeFailure] otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitCodeUsage
+	}
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return ExitCodePanic
+	}
+
+	return ExitCodeFailure
+}
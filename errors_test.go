@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitCodeOK},
+		{"plain", errors.New("failed"), ExitCodeFailure},
+		{"exit error", &ExitError{Code: 3, Err: errors.New("failed")}, 3},
+		{"wrapped exit error", fmt.Errorf("run: %w", NotFoundError(errors.New("missing"))), ExitCodeNotFound},
+		{"config", ConfigError(errors.New("invalid")), ExitCodeConfig},
+		{"interrupted", InterruptedError(errors.New("interrupted")), ExitCodeInterrupted},
+		{"usage", fmt.Errorf("run: %w", usageErrorf("bad input")), ExitCodeUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestExitCode_CommandErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantCode  int
+		wantUsage bool
+	}{
+		{"unknown flag", []string{"compare", "--unknown"}, ExitCodeUsage, true},
+		{"args", []string{"compare", "a", "b"}, ExitCodeUsage, true},
+		{"config", []string{"compare", "a", "--config", filepath.Join(t.TempDir(), "missing.yaml")}, ExitCodeConfig, false},
+		{"handler", []string{"compare", "a"}, ExitCodeNotFound, false},
+		{"unknown command", []string{"comprae"}, ExitCodeUsage, false},
+		{"pre-run", []string{"compare", "a", "--fail-pre-run"}, ExitCodeFailure, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := Root("acme", "CLI sample application",
+				Command(func(cmd *cobra.Command, args []string) error {
+					return NotFoundError(errors.New("input not found"))
+				}, "compare", "Compare files", ExactArgs(1)),
+				PersistentFlags(func(flags *pflag.FlagSet) { flags.Bool("fail-pre-run", false, "") }),
+				CommandOptionFunc(func(cmd *cobra.Command) {
+					cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+						if failed, _ := cmd.Flags().GetBool("fail-pre-run"); failed {
+							return errors.New("pre-run failed")
+						}
+
+						return nil
+					}
+				}),
+				ConfigureConfigFile("acme"),
+				ConfigureViperInstance(viper.New(), "ACME"),
+			)
+			// Same setup as performed by Run
+			visitAllCommands(root, func(cmd *cobra.Command) {
+				if cmd.RunE != nil {
+					cmd.RunE = silenceUsageOnError(cmd.RunE)
+					cmd.SilenceUsage = false
+				}
+			})
+
+			root.SetArgs(tt.args)
+
+			executed, err := root.ExecuteC()
+			assert.Equal(t, tt.wantCode, ExitCode(cobraUsageError(err)))
+			assert.Equal(t, tt.wantUsage, !executed.SilenceUsage)
+		})
+	}
+}
@@ -45,7 +45,7 @@ func main() {
 				compare relative_file.json
 				compare /absolute/file.json
 			`),
-			ExactArgs(2),
+			ExactArgs(1),
 		),
 
 		OnCommandErrorLogAndExit(zlog),
@@ -40,7 +40,7 @@ var compareCmd = &cobra.Command{
 		"use 'go run ./example/nested compare --help' to see it in action!",
 	Example: "runner compare relative_file.json\n" +
 		"runner compare /absolute/file.json",
-	Args: cobra.ExactArgs(2),
+	Args: cobra.ExactArgs(1),
 	RunE: compareE,
 }
 
@@ -39,17 +39,18 @@ func main() {
 		),
 
 		Command(compareE,
-			"compare <input_file>",
-			"Quick command summary, with a description, the actual usage above is descriptive, you must handle the arguments manually",
+			"compare",
+			"Quick command summary, with a description, the usage line and the arguments validation are generated from the declared arguments",
 			Description(`
 				Description of the command, automatically de-indented by using first line indentation,
 				use 'go run ./example/nested compare --help' to see it in action!
 			`),
 			ExamplePrefixed("runner", `
 				compare relative_file.json
-				compare /absolute/file.json
+				compare /absolute/file.json 3
 			`),
-			ExactArgs(2),
+			Arg[string]("input_file", "The file to compare"),
+			Arg[int]("context", "Number of context lines shown around each difference, 5 if not provided", ArgOptional()),
 		),
 
 		OnCommandErrorLogAndExit(zlog),
@@ -68,9 +69,16 @@ func generateImgE(cmd *cobra.Command, args []string) error {
 }
 
 func compareE(cmd *cobra.Command, args []string) error {
+	inputFile := MustGetArg[string](cmd, "input_file")
+
+	context := 5
+	if ArgProvided(cmd, "context") {
+		context = MustGetArg[int](cmd, "context")
+	}
+
 	shouldContinue, wasAnswered := cli.PromptConfirm(`Do you want to continue?`)
 	if wasAnswered && shouldContinue {
-		fmt.Println("Showing diff between files")
+		fmt.Printf("Showing diff of %s with %d lines of context\n", inputFile, context)
 	} else {
 		fmt.Println("Not showing diff between files, run the following command to see it manually:")
 	}
@@ -1,19 +1,32 @@
 package cli
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"sort"
+	"sync"
+	"time"
 
-	"github.com/bobg/go-generics/v2/slices"
+	"go.uber.org/zap"
 )
 
-var globalExitManager = &exitManager{}
+// DefaultExitHandlerTimeout is the maximum time an exit handler can take before it's
+// abandoned, see [WithExitHandlerTimeout] to configure it per handler. A value of 0 or lower,
+// the default, means no timeout, the process waits for the handler to complete.
+var DefaultExitHandlerTimeout time.Duration
+
+var globalExitManager = &exitManager{out: os.Stderr}
 
 // Exit executes the registered exit handlers and then call `os.Exit(code)`.
 // This can be used to implement a trap behavior. Of course, you must ensure
 // that `os.Exit` are all done through `cli.Exit()` otherwise we will not be invoked.
 //
-// **Caveats** Panics are not recovered by those exit handlers, right now you need to implement your
-// own trapping and then exit through `cli.Exit`.
+// Panics of commands executed through [Run] and of children started with [Application.SuperviseAndStart]
+// are recovered and exit through `cli.Exit(cli.ExitCodePanic)`.
+//
+// **Caveats** Other panics are not recovered by those exit handlers, you need to implement your
+// own trapping (goroutines you start yourself for example) and then exit through `cli.Exit`.
 //
 // This library use `cli.Exit(code)` throughout so quitting due to `cli.NoError` or
 // `cli.Ensure` will correctly call the exit handlers.
@@ -24,34 +37,174 @@ func Exit(code int) {
 
 // ExitHandler registers or unregisters an exit handler. If the `onExit` received
 // is nil, unregister the handler with given `id`. Otherwise, register or update an
-// existing one.
+// existing one, an updated handler keeps its original registration order.
 //
 // No collision is checked, so an id overrides any previously existing id. This library
 // is meant to be used on final CLI product, you should have low number of exit handler,
 // pick your id and keep them short.
-func ExitHandler(id string, onExit func(code int)) {
-	globalExitManager.updateHandler(id, onExit)
+//
+// Handlers are executed by order of priority, highest first, and then in reverse order of
+// registration (last registered runs first) so that resources are released in the opposite
+// order they were acquired. Each handler is isolated from the others, a handler that panics or
+// times out (see [WithExitHandlerTimeout]) does not prevent the next ones from running. Handlers
+// that failed are reported on stderr and through the logger once all of them ran.
+func ExitHandler(id string, onExit func(code int), opts ...ExitHandlerOption) {
+	if onExit == nil {
+		globalExitManager.updateHandler(id, nil, opts)
+		return
+	}
+
+	globalExitManager.updateHandler(id, func(code int) error { onExit(code); return nil }, opts)
+}
+
+// ExitHandlerE is like [ExitHandler] but the handler can return an error, reported as a
+// failure of the handler.
+func ExitHandlerE(id string, onExit func(code int) error, opts ...ExitHandlerOption) {
+	globalExitManager.updateHandler(id, onExit, opts)
+}
+
+type ExitHandlerOption interface {
+	Apply(handler *exitHandler)
+}
+
+type exitHandlerPriorityOption int
+
+func (o exitHandlerPriorityOption) Apply(handler *exitHandler) {
+	handler.Priority = int(o)
+}
+
+// WithExitHandlerPriority sets the priority of the handler, handlers with a higher priority
+// run first. The default priority is 0.
+func WithExitHandlerPriority(priority int) ExitHandlerOption {
+	return exitHandlerPriorityOption(priority)
+}
+
+type exitHandlerTimeoutOption time.Duration
+
+func (o exitHandlerTimeoutOption) Apply(handler *exitHandler) {
+	handler.Timeout = time.Duration(o)
+}
+
+// WithExitHandlerTimeout sets the maximum time the handler can take, [DefaultExitHandlerTimeout]
+// is used if not provided. A value of 0 or lower means no timeout.
+func WithExitHandlerTimeout(timeout time.Duration) ExitHandlerOption {
+	return exitHandlerTimeoutOption(timeout)
+}
+
+// ExitHandlerFailure describes an exit handler that failed, timed out or panicked.
+type ExitHandlerFailure struct {
+	ID  string
+	Err error
 }
 
 type exitManager struct {
+	lock     sync.Mutex
+	sequence int
+	out      io.Writer
+
 	Handlers []exitHandler
 }
 
-func (m *exitManager) onExit(code int) {
-	for _, handler := range m.Handlers {
-		handler.Handler(code)
+func (m *exitManager) onExit(code int) (failures []ExitHandlerFailure) {
+	m.lock.Lock()
+	handlers := make([]exitHandler, len(m.Handlers))
+	copy(handlers, m.Handlers)
+	m.lock.Unlock()
+
+	sort.SliceStable(handlers, func(i, j int) bool {
+		if handlers[i].Priority != handlers[j].Priority {
+			return handlers[i].Priority > handlers[j].Priority
+		}
+
+		return handlers[i].sequence > handlers[j].sequence
+	})
+
+	for _, handler := range handlers {
+		if err := handler.run(code); err != nil {
+			failures = append(failures, ExitHandlerFailure{handler.ID, err})
+		}
+	}
+
+	if len(failures) > 0 {
+		// The logger is a no-op unless the application configured logging, the failures are
+		// written to stderr too so they are never lost
+		for _, failure := range failures {
+			if m.out != nil {
+				fmt.Fprintf(m.out, "exit handler %q failed: %s\n", failure.ID, failure.Err)
+			}
+
+			zlog.Error("exit handler failed", zap.String("id", failure.ID), zap.Error(failure.Err))
+		}
+
+		zlog.Error(fmt.Sprintf("%d of %d exit handlers failed", len(failures), len(handlers)))
+		zlog.Sync()
 	}
+
+	return failures
 }
 
-func (m *exitManager) updateHandler(id string, onExit func(code int)) {
-	if onExit == nil {
-		m.Handlers = slices.Filter(m.Handlers, func(handler exitHandler) bool { return handler.ID != id })
-	} else {
-		m.Handlers = append(m.Handlers, exitHandler{id, onExit})
+func (m *exitManager) updateHandler(id string, onExit func(code int) error, opts []ExitHandlerOption) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for i, handler := range m.Handlers {
+		if handler.ID != id {
+			continue
+		}
+
+		if onExit == nil {
+			m.Handlers = append(m.Handlers[:i], m.Handlers[i+1:]...)
+			return
+		}
+
+		m.Handlers[i] = newExitHandler(id, onExit, handler.sequence, opts)
+		return
+	}
+
+	if onExit != nil {
+		m.sequence++
+		m.Handlers = append(m.Handlers, newExitHandler(id, onExit, m.sequence, opts))
 	}
 }
 
 type exitHandler struct {
-	ID      string
-	Handler func(code int)
+	ID       string
+	Handler  func(code int) error
+	Priority int
+	Timeout  time.Duration
+
+	sequence int
+}
+
+func newExitHandler(id string, onExit func(code int) error, sequence int, opts []ExitHandlerOption) exitHandler {
+	handler := exitHandler{ID: id, Handler: onExit, Timeout: DefaultExitHandlerTimeout, sequence: sequence}
+	for _, opt := range opts {
+		opt.Apply(&handler)
+	}
+
+	return handler
+}
+
+func (h exitHandler) run(code int) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("panic: %v", recovered)
+			}
+		}()
+
+		done <- h.Handler(code)
+	}()
+
+	if h.Timeout <= 0 {
+		return <-done
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(h.Timeout):
+		return fmt.Errorf("timed out after %s", h.Timeout)
+	}
 }
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitManager(t *testing.T) {
+	var calls []string
+	record := func(id string) func(code int) error {
+		return func(code int) error {
+			calls = append(calls, id)
+			return nil
+		}
+	}
+
+	var out bytes.Buffer
+	manager := &exitManager{out: &out}
+	manager.updateHandler("first", record("first"), nil)
+	manager.updateHandler("second", record("second"), nil)
+	manager.updateHandler("priority", record("priority"), []ExitHandlerOption{WithExitHandlerPriority(10)})
+	manager.updateHandler("third", record("third"), nil)
+	manager.updateHandler("first", record("first-updated"), nil)
+	manager.updateHandler("removed", record("removed"), nil)
+	manager.updateHandler("removed", nil, nil)
+
+	failing := func(code int) error { return errors.New("flush failed") }
+	panicking := func(code int) error { panic("boom") }
+	blocking := func(code int) error { time.Sleep(time.Second); return nil }
+
+	manager.updateHandler("failing", failing, []ExitHandlerOption{WithExitHandlerPriority(-1)})
+	manager.updateHandler("panicking", panicking, []ExitHandlerOption{WithExitHandlerPriority(-1)})
+	manager.updateHandler("blocking", blocking, []ExitHandlerOption{WithExitHandlerPriority(-1), WithExitHandlerTimeout(10 * time.Millisecond)})
+	manager.updateHandler("last", record("last"), []ExitHandlerOption{WithExitHandlerPriority(-2)})
+
+	failures := manager.onExit(1)
+
+	assert.Equal(t, []string{"priority", "third", "second", "first-updated", "last"}, calls)
+	assert.Equal(t, []ExitHandlerFailure{
+		{"blocking", errors.New("timed out after 10ms")},
+		{"panicking", errors.New("panic: boom")},
+		{"failing", errors.New("flush failed")},
+	}, failures)
+	assert.Equal(t, "exit handler \"blocking\" failed: timed out after 10ms\n"+
+		"exit handler \"panicking\" failed: panic: boom\n"+
+		"exit handler \"failing\" failed: flush failed\n", out.String())
+}
+
+func TestExitManager_NoTimeoutByDefault(t *testing.T) {
+	manager := &exitManager{}
+	manager.updateHandler("slow", func(code int) error { time.Sleep(50 * time.Millisecond); return nil }, nil)
+
+	assert.Equal(t, time.Duration(0), manager.Handlers[0].Timeout)
+	assert.Empty(t, manager.onExit(1))
+}
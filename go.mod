@@ -14,16 +14,14 @@ require (
 	go.uber.org/zap v1.21.0
 )
 
-require golang.org/x/exp v0.0.0-20230213192124-5e25df0256eb // indirect
+require golang.org/x/exp v0.0.0-20230213192124-5e25df0256eb
 
 require (
 	github.com/blendle/zapdriver v1.3.1 // indirect
-	github.com/bobg/go-generics/v2 v2.1.1
 	github.com/chzyer/readline v0.0.0-20180603132655-2972be24d48e // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
-	github.com/fsnotify/fsnotify v1.6.0 // indirect
+	github.com/fsnotify/fsnotify v1.6.0
 	github.com/hashicorp/hcl v1.0.0 // indirect
-	github.com/iancoleman/strcase v0.2.0
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/juju/ansiterm v0.0.0-20180109212912-720a0952cc2a // indirect
 	github.com/logrusorgru/aurora v2.0.3+incompatible // indirect
@@ -36,7 +34,7 @@ require (
 	github.com/pelletier/go-toml/v2 v2.0.6 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/spf13/afero v1.9.3 // indirect
-	github.com/spf13/cast v1.5.0 // indirect
+	github.com/spf13/cast v1.5.0
 	github.com/spf13/jwalterweatherman v1.1.0 // indirect
 	github.com/subosito/gotenv v1.4.2 // indirect
 	go.uber.org/atomic v1.9.0
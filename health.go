@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReadinessChecker can be implemented by children supervised by the [Application] to report
+// their readiness, see [Application.ServeHealth].
+type ReadinessChecker interface {
+	IsReady() bool
+}
+
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// AddReadinessCheck registers a check contributing to the readiness of the application as
+// reported by `/readyz`, see [Application.ServeHealth]. The check must return an error when
+// not ready. Supervised children implementing [ReadinessChecker] are registered automatically.
+func (a *Application) AddReadinessCheck(name string, check func(ctx context.Context) error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.readinessChecks = append(a.readinessChecks, readinessCheck{name, check})
+}
+
+// ServeHealth starts an HTTP server listening on `addr` exposing the health of the application,
+// the server is closed when the application terminates:
+//
+//	/healthz   Liveness, fails once the application or one of its supervised children terminates
+//	/readyz    Readiness, fails once the application has been signaled (see [Application.IsReady]),
+//	           is terminating or one of the readiness checks fails
+//
+// Both endpoints answer `200 OK` or `503 Service Unavailable` with a JSON body detailing each check.
+func (a *Application) ServeHealth(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %q: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, a.liveness())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, a.readiness(r.Context()))
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	a.shutter.OnTerminated(func(_ error) { server.Close() })
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zlog.Error("health server failed", zap.String("addr", addr), zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func (a *Application) liveness() map[string]string {
+	checks := map[string]string{"application": healthStatus(a.shutter.IsTerminated(), "terminated")}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, child := range a.children {
+		checks[child.name] = healthStatus(child.isTerminated(), "terminated")
+	}
+
+	return checks
+}
+
+func (a *Application) readiness(ctx context.Context) map[string]string {
+	checks := map[string]string{
+		"signal":      healthStatus(a.isSignaled.Load(), "signaled"),
+		"application": healthStatus(a.shutter.IsTerminating(), "terminating"),
+	}
+
+	a.lock.Lock()
+	readinessChecks := make([]readinessCheck, len(a.readinessChecks))
+	copy(readinessChecks, a.readinessChecks)
+	a.lock.Unlock()
+
+	for _, check := range readinessChecks {
+		checks[check.name] = "ok"
+		if err := check.check(ctx); err != nil {
+			checks[check.name] = err.Error()
+		}
+	}
+
+	return checks
+}
+
+func healthStatus(failed bool, failure string) string {
+	if failed {
+		return failure
+	}
+
+	return "ok"
+}
+
+func writeHealth(w http.ResponseWriter, checks map[string]string) {
+	status := http.StatusOK
+	for _, check := range checks {
+		if check != "ok" {
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(checks)
+}
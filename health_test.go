@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/streamingfast/shutter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+type readyChild struct {
+	*shutter.Shutter
+	ready atomic.Bool
+}
+
+func (c *readyChild) IsReady() bool { return c.ready.Load() }
+
+func TestApplication_ServeHealth(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	listener.Close()
+
+	app := NewApplication(context.Background())
+	child := &readyChild{Shutter: shutter.New()}
+	app.Supervise(child)
+
+	var external atomic.Error
+	external.Store(errors.New("warming up"))
+	app.AddReadinessCheck("cache", func(_ context.Context) error { return external.Load() })
+
+	require.NoError(t, app.ServeHealth(addr))
+
+	get := func(path string) (int, map[string]string) {
+		response, err := http.Get("http://" + addr + path)
+		require.NoError(t, err)
+		defer response.Body.Close()
+
+		var checks map[string]string
+		require.NoError(t, json.NewDecoder(response.Body).Decode(&checks))
+		return response.StatusCode, checks
+	}
+
+	status, checks := get("/healthz")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]string{"application": "ok", "*cli.readyChild": "ok"}, checks)
+
+	status, checks = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, map[string]string{"signal": "ok", "application": "ok", "*cli.readyChild": "not ready", "cache": "warming up"}, checks)
+
+	child.ready.Store(true)
+	external.Store(nil)
+
+	status, _ = get("/readyz")
+	assert.Equal(t, http.StatusOK, status)
+
+	app.isSignaled.Store(true)
+	status, checks = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "signaled", checks["signal"])
+}
+
+func TestApplication_Liveness(t *testing.T) {
+	app := NewApplication(context.Background())
+	child := &readyChild{Shutter: shutter.New()}
+	app.Supervise(child)
+
+	child.Shutdown(errors.New("failed"))
+	<-app.shutter.Terminated()
+
+	assert.Equal(t, map[string]string{"application": "terminated", "*cli.readyChild": "terminated"}, app.liveness())
+}
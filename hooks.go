@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var annotationPreRunHooks = "pre-run-hooks"
+var annotationAfterRunHooks = "after-run-hooks"
+
+// preRunHook is executed once the command line has been parsed and right before the
+// actual command's `RunE` is invoked. Hooks registered on a command are executed for
+// the command itself and all of its sub-commands.
+//
+// Hooks are executed phase by phase, within a phase, parent's hooks are run first and
+// then in registration order.
+type preRunHook func(cmd *cobra.Command) error
+
+type preRunPhase int
+
+const (
+	// preRunPhaseLoad loads the configuration sources (config file for example)
+	preRunPhaseLoad preRunPhase = iota
+	// preRunPhaseResolve computes values derived from the loaded configuration (secret files for example)
+	preRunPhaseResolve
+	// preRunPhaseValidate validates the fully resolved configuration
+	preRunPhaseValidate
+	// preRunPhaseReport inspects the fully resolved configuration
+	preRunPhaseReport
+)
+
+type phasedPreRunHook struct {
+	phase preRunPhase
+	hook  preRunHook
+}
+
+// addPreRunHook registers the `hook` on `cmd` for the given `phase`, see preRunHook for
+// execution rules. The actual runner is installed on the root command by [Root].
+func addPreRunHook(cmd *cobra.Command, phase preRunPhase, hook preRunHook) {
+	var hooks []phasedPreRunHook
+	if existing, found := getCommandAnnotation(cmd, annotationPreRunHooks); found {
+		hooks = existing.([]phasedPreRunHook)
+	}
+
+	setCommandAnnotation(cmd, annotationPreRunHooks, append(hooks, phasedPreRunHook{phase, hook}))
+}
+
+// installPreRunHooks sets up the root's `PersistentPreRunE` to execute the registered
+// hooks, chaining to any previously defined persistent pre-run function.
+//
+// **Caveats** Cobra only executes the first `PersistentPreRun(E)` found walking up from the
+// executing command, so a sub-command defining its own will bypass the hooks.
+func installPreRunHooks(root *cobra.Command) {
+	previousE := root.PersistentPreRunE
+	previous := root.PersistentPreRun
+
+	root.PersistentPreRun = nil
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := runPreRunHooks(cmd); err != nil {
+			runAfterRunHooks(cmd)
+
+			// Only usage errors should be followed by the command's usage
+			if code := ExitCode(err); code != ExitCodeUsage && code != ExitCodeFailure {
+				cmd.SilenceUsage = true
+			}
+
+			return err
+		}
+
+		if previousE != nil {
+			return previousE(cmd, args)
+		}
+
+		if previous != nil {
+			previous(cmd, args)
+		}
+
+		return nil
+	}
+}
+
+func runPreRunHooks(cmd *cobra.Command) error {
+	// Completion requests are served by cobra before the configuration is needed
+	if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+		return nil
+	}
+
+	var chain []*cobra.Command
+	for current := cmd; current != nil; current = current.Parent() {
+		chain = append([]*cobra.Command{current}, chain...)
+	}
+
+	var hooks []phasedPreRunHook
+	for _, current := range chain {
+		if existing, found := getCommandAnnotation(current, annotationPreRunHooks); found {
+			hooks = append(hooks, existing.([]phasedPreRunHook)...)
+		}
+	}
+
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].phase < hooks[j].phase })
+
+	for _, hook := range hooks {
+		// Internal commands inspect the configuration, they must work even if it's invalid
+		if hook.phase == preRunPhaseValidate && isInternalCommand(cmd) {
+			continue
+		}
+
+		if err := hook.hook(cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// skipRun makes cobra skip the pre-run and run functions of `cmd` for the current execution
+// only, for hooks fully handling the execution like `--print-config` does. The execution then
+// succeeds without running the command.
+func skipRun(cmd *cobra.Command) {
+	preRun, preRunE, run, runE := cmd.PreRun, cmd.PreRunE, cmd.Run, cmd.RunE
+
+	cmd.PreRun, cmd.PreRunE, cmd.Run = nil, nil, nil
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cmd.PreRun, cmd.PreRunE, cmd.Run, cmd.RunE = preRun, preRunE, run, runE
+		runAfterRunHooks(cmd)
+		return nil
+	}
+}
+
+type afterRunHooks struct {
+	restore func()
+	hooks   []func()
+}
+
+// addAfterRunHook registers `hook` to be called once the current execution of `cmd` is over,
+// that is once its run function returned or as soon as its pre-run failed, for pre-run hooks
+// having to undo what they did for the execution only. Hooks are called in reverse order of
+// registration.
+func addAfterRunHook(cmd *cobra.Command, hook func()) {
+	if existing, _ := getCommandAnnotation(cmd, annotationAfterRunHooks); existing != nil {
+		pending := existing.(*afterRunHooks)
+		pending.hooks = append(pending.hooks, hook)
+		return
+	}
+
+	preRun, preRunE, run, runE := cmd.PreRun, cmd.PreRunE, cmd.Run, cmd.RunE
+	setCommandAnnotation(cmd, annotationAfterRunHooks, &afterRunHooks{
+		restore: func() { cmd.PreRun, cmd.PreRunE, cmd.Run, cmd.RunE = preRun, preRunE, run, runE },
+		hooks:   []func(){hook},
+	})
+
+	cmd.PreRun, cmd.Run = nil, nil
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) (err error) {
+		if preRunE != nil {
+			err = preRunE(cmd, args)
+		} else if preRun != nil {
+			preRun(cmd, args)
+		}
+
+		if err != nil {
+			runAfterRunHooks(cmd)
+		}
+
+		return err
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		defer runAfterRunHooks(cmd)
+
+		if runE != nil {
+			return runE(cmd, args)
+		}
+
+		run(cmd, args)
+		return nil
+	}
+}
+
+// runAfterRunHooks calls the hooks registered by [addAfterRunHook] for the current execution
+// of `cmd`, if any, and restores its pre-run and run functions.
+func runAfterRunHooks(cmd *cobra.Command) {
+	existing, _ := getCommandAnnotation(cmd, annotationAfterRunHooks)
+	if existing == nil {
+		return
+	}
+
+	pending := existing.(*afterRunHooks)
+	setCommandAnnotation(cmd, annotationAfterRunHooks, nil)
+	pending.restore()
+
+	for i := len(pending.hooks) - 1; i >= 0; i-- {
+		pending.hooks[i]()
+	}
+}
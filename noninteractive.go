@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+// ErrNonInteractive is returned by prompts that require an answer while prompting is not
+// possible, see [ConfigureNonInteractive].
+var ErrNonInteractive = errors.New("prompt requires an answer but input is disabled")
+
+type promptModeState struct {
+	nonInteractive bool
+	assumeYes      bool
+	envPrefix      string
+	answers        *viper.Viper
+}
+
+var promptModeLock sync.RWMutex
+var promptMode promptModeState
+
+func currentPromptMode() promptModeState {
+	promptModeLock.RLock()
+	defer promptModeLock.RUnlock()
+
+	return promptMode
+}
+
+// setPromptMode replaces the prompt mode, returning the previous one.
+func setPromptMode(mode promptModeState) (previous promptModeState) {
+	promptModeLock.Lock()
+	defer promptModeLock.Unlock()
+
+	previous, promptMode = promptMode, mode
+	return previous
+}
+
+// ConfigureNonInteractive adds the `--no-input`, `--yes` and `--answers-file` persistent flags
+// on the [cobra.Command] to control the prompts of the commands.
+//
+// With `--no-input`, or when `{PREFIX}_NON_INTERACTIVE` environment variable is true, prompts
+// never wait for the user: they use their default value and fail with [ErrNonInteractive] when
+// they have none, confirmations without default value being answered no. `--yes` implies `--no-input` but answers yes
+// to confirmations. Prompts always behave this way when stdout is not a terminal.
+//
+// Prompts with an id (see [WithPromptID] and [WithPromptSelectID]) can be answered in advance
+// through `{PREFIX}_ANSWER_<ID>` environment variable or through the `<id>` key of the file
+// passed to `--answers-file` (any of [ConfigFileTypes]), the environment variable winning:
+//
+//	deploy-confirm: yes
+//	network: mainnet
+//
+// The `{PREFIX}` is the one received by [ConfigureViper], environment variables are not looked
+// up if it's not configured.
+//
+// The prompt mode is only effective while the command executes, the previous one being restored
+// once it's done.
+func ConfigureNonInteractive() CommandOption {
+	return CommandOptionFunc(func(root *cobra.Command) {
+		root.PersistentFlags().Bool("no-input", false, "Never prompt, prompts use their default value and fail if they have none")
+		root.PersistentFlags().Bool("yes", false, "Answer yes to all confirmations, implies --no-input")
+		root.PersistentFlags().String("answers-file", "", "File providing the answers to prompts by prompt id")
+
+		addPreRunHook(root, preRunPhaseResolve, func(cmd *cobra.Command) error {
+			v := ViperFor(cmd)
+
+			var mode promptModeState
+			var err error
+			if mode.nonInteractive, err = rootFlagBool(root, v, "no-input"); err != nil {
+				return err
+			}
+
+			if mode.assumeYes, err = rootFlagBool(root, v, "yes"); err != nil {
+				return err
+			}
+
+			if prefix, found := getCommandAnnotation(root, annotationEnvPrefix); found && prefix != "" {
+				mode.envPrefix = prefix.(string)
+
+				name := envVarName(root, "non-interactive")
+				if value, found := os.LookupEnv(name); found && value != "" {
+					enabled, err := strconv.ParseBool(value)
+					if err != nil {
+						return ConfigError(fmt.Errorf("invalid %s value %q: %w", name, value, err))
+					}
+
+					mode.nonInteractive = mode.nonInteractive || enabled
+				}
+			}
+
+			if path := rootFlagValue(root, v, "answers-file"); path != "" {
+				answers := viper.New()
+				answers.SetConfigFile(path)
+				if err := answers.ReadInConfig(); err != nil {
+					return ConfigError(fmt.Errorf("read answers file %q: %w", path, err))
+				}
+
+				mode.answers = answers
+			}
+
+			// Prompts are not bound to a command, the mode is process wide, it's only effective
+			// while the command executes so it does not leak into other command trees
+			previous := setPromptMode(mode)
+			addAfterRunHook(cmd, func() { setPromptMode(previous) })
+
+			return nil
+		})
+	})
+}
+
+// rootFlagBool returns the boolean value of the persistent flag `name` of `root`, accepting
+// any value of [strconv.ParseBool] when it comes from the environment or the config file.
+func rootFlagBool(root *cobra.Command, v *viper.Viper, name string) (bool, error) {
+	value := rootFlagValue(root, v, name)
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, ConfigError(fmt.Errorf("invalid --%s value %q: %w", name, value, err))
+	}
+
+	return enabled, nil
+}
+
+// SetNonInteractive enables or disables the non-interactive mode of prompts, see
+// [ConfigureNonInteractive] to control it from the command line.
+func SetNonInteractive(enabled bool) {
+	promptModeLock.Lock()
+	defer promptModeLock.Unlock()
+
+	promptMode.nonInteractive = enabled
+}
+
+// IsInteractive returns true if prompts can wait for the user's answer, that is when the
+// non-interactive mode is disabled and stdout is a terminal.
+func IsInteractive() bool {
+	mode := currentPromptMode()
+	if mode.nonInteractive || mode.assumeYes {
+		return false
+	}
+
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// scriptedAnswer returns the answer provided in advance for the prompt `id`, if any.
+func scriptedAnswer(id string) (string, bool) {
+	if id == "" {
+		return "", false
+	}
+
+	mode := currentPromptMode()
+	if mode.envPrefix != "" {
+		if answer, found := os.LookupEnv(answerEnvVar(mode.envPrefix, id)); found {
+			return answer, true
+		}
+	}
+
+	if mode.answers != nil && mode.answers.IsSet(id) {
+		return mode.answers.GetString(id), true
+	}
+
+	return "", false
+}
+
+func answerEnvVar(envPrefix, id string) string {
+	return envKeyReplacer.Replace(strings.ToUpper(envPrefix + "_ANSWER_" + id))
+}
+
+// nonInteractiveAnswer returns the answer of a prompt when prompting is not possible.
+func nonInteractiveAnswer(label string, options promptOptions) (string, error) {
+	if options.isConfirm && currentPromptMode().assumeYes {
+		return "yes", nil
+	}
+
+	if options.defaultValue != "" {
+		return options.defaultValue, nil
+	}
+
+	if options.isConfirm {
+		return "no", nil
+	}
+
+	return "", nonInteractiveError(label, options.id)
+}
+
+func nonInteractiveError(label string, id string) error {
+	if id == "" {
+		return fmt.Errorf("%w: %q has no default value", ErrNonInteractive, label)
+	}
+
+	hint := fmt.Sprintf("key %q of the answers file", id)
+	if envPrefix := currentPromptMode().envPrefix; envPrefix != "" {
+		hint = answerEnvVar(envPrefix, id) + " or " + hint
+	}
+
+	return fmt.Errorf("%w: %q has no default value, provide it through %s", ErrNonInteractive, label, hint)
+}
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptRaw_NonInteractive(t *testing.T) {
+	answersFile := filepath.Join(t.TempDir(), "answers.yaml")
+	WriteFile(answersFile, "%s", "user-id: from-file\nage: ten\n")
+
+	answers := viper.New()
+	answers.SetConfigFile(answersFile)
+	require.NoError(t, answers.ReadInConfig())
+
+	tests := []struct {
+		name        string
+		mode        promptModeState
+		env         map[string]string
+		opts        []PromptOption
+		expected    string
+		expectedErr string
+	}{
+		{"default value", promptModeState{}, nil, []PromptOption{WithPromptDefaultValue("alice")}, "alice", ""},
+		{"no default", promptModeState{}, nil, nil, "", `prompt requires an answer but input is disabled: "Enter user ID" has no default value`},
+		{
+			"no default with id",
+			promptModeState{envPrefix: "ACME"},
+			nil,
+			[]PromptOption{WithPromptID("user-id")},
+			"",
+			`prompt requires an answer but input is disabled: "Enter user ID" has no default value, provide it through ACME_ANSWER_USER_ID or key "user-id" of the answers file`,
+		},
+		{"confirm", promptModeState{}, nil, []PromptOption{WithPromptConfirm()}, "no", ""},
+		{"confirm assume yes", promptModeState{assumeYes: true}, nil, []PromptOption{WithPromptConfirm()}, "yes", ""},
+		{"answer from env", promptModeState{envPrefix: "ACME", answers: answers}, map[string]string{"ACME_ANSWER_USER_ID": "from-env"}, []PromptOption{WithPromptID("user-id")}, "from-env", ""},
+		{"answer from file", promptModeState{envPrefix: "ACME", answers: answers}, nil, []PromptOption{WithPromptID("user-id")}, "from-file", ""},
+		{"env ignored without prefix", promptModeState{}, map[string]string{"_ANSWER_USER_ID": "from-env"}, []PromptOption{WithPromptID("user-id"), WithPromptDefaultValue("alice")}, "alice", ""},
+		{
+			"invalid answer",
+			promptModeState{answers: answers},
+			nil,
+			[]PromptOption{WithPromptID("age"), WithPromptValidate("invalid age", PrompValidateUint64)},
+			"",
+			`invalid answer "ten" for prompt "age": invalid age: strconv.ParseUint: parsing "ten": invalid syntax`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPromptMode(tt.mode)
+			defer setPromptMode(promptModeState{})
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			answer, err := PromptRaw("Enter user ID", tt.opts...)
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, answer)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestMaybePromptConfirm_NonInteractive(t *testing.T) {
+	defer setPromptMode(promptModeState{})
+
+	answer, wasAnswered, err := MaybePromptConfirm("Continue?")
+	require.NoError(t, err)
+	assert.False(t, answer)
+	assert.False(t, wasAnswered)
+
+	setPromptMode(promptModeState{assumeYes: true})
+	answer, wasAnswered, err = MaybePromptConfirm("Continue?")
+	require.NoError(t, err)
+	assert.True(t, answer)
+	assert.True(t, wasAnswered)
+
+	answeredYes, wasAnswered := AskConfirmation("Continue?")
+	assert.True(t, answeredYes)
+	assert.True(t, wasAnswered)
+
+	setPromptMode(promptModeState{nonInteractive: true})
+	answer, wasAnswered, err = MaybePromptConfirm("Continue?", WithPromptDefaultValue("y"))
+	require.NoError(t, err)
+	assert.True(t, answer)
+	assert.True(t, wasAnswered)
+
+	setPromptMode(promptModeState{envPrefix: "ACME"})
+	t.Setenv("ACME_ANSWER_CONTINUE", "n")
+	answer, wasAnswered, err = MaybePromptConfirm("Continue?", WithPromptID("continue"))
+	require.NoError(t, err)
+	assert.False(t, answer)
+	assert.True(t, wasAnswered)
+}
+
+func TestMaybePromptSelect_NonInteractive(t *testing.T) {
+	setPromptMode(promptModeState{envPrefix: "ACME"})
+	defer setPromptMode(promptModeState{})
+
+	networks := []string{"mainnet", "testnet"}
+
+	_, err := MaybePromptSelect("Network", networks, PromptTypeString, WithPromptSelectID("network"))
+	assert.ErrorIs(t, err, ErrNonInteractive)
+
+	selection, err := MaybePromptSelect("Network", networks, PromptTypeString, WithPromptSelectDefaultValue("testnet"))
+	require.NoError(t, err)
+	assert.Equal(t, "testnet", selection)
+
+	_, err = MaybePromptSelect("Network", networks, PromptTypeString, WithPromptSelectDefaultValue("devnet"))
+	assert.EqualError(t, err, `invalid default value "devnet" for prompt "Network", must be one of mainnet, testnet`)
+
+	t.Setenv("ACME_ANSWER_NETWORK", "devnet")
+	_, err = MaybePromptSelect("Network", networks, PromptTypeString, WithPromptSelectID("network"))
+	assert.EqualError(t, err, `invalid answer "devnet" for prompt "network", must be one of mainnet, testnet`)
+
+	t.Setenv("ACME_ANSWER_NETWORK", "mainnet")
+	selection, err = MaybePromptSelect("Network", networks, PromptTypeString, WithPromptSelectID("network"))
+	require.NoError(t, err)
+	assert.Equal(t, "mainnet", selection)
+}
+
+func TestConfigureNonInteractive(t *testing.T) {
+	answersFile := filepath.Join(t.TempDir(), "answers.json")
+	WriteFile(answersFile, "%s", `{"user-id": "bob"}`)
+
+	tests := []struct {
+		name        string
+		args        []string
+		env         map[string]string
+		expected    promptModeState
+		expectedErr string
+	}{
+		{"defaults", nil, nil, promptModeState{envPrefix: "ACME"}, ""},
+		{"no input flag", []string{"--no-input"}, nil, promptModeState{nonInteractive: true, envPrefix: "ACME"}, ""},
+		{"yes flag", []string{"--yes"}, nil, promptModeState{assumeYes: true, envPrefix: "ACME"}, ""},
+		{"env", nil, map[string]string{"ACME_NON_INTERACTIVE": "true"}, promptModeState{nonInteractive: true, envPrefix: "ACME"}, ""},
+		{"no input rebound env", nil, map[string]string{"ACME_GLOBAL_NO_INPUT": "1"}, promptModeState{nonInteractive: true, envPrefix: "ACME"}, ""},
+		{"yes rebound env", nil, map[string]string{"ACME_GLOBAL_YES": "True"}, promptModeState{assumeYes: true, envPrefix: "ACME"}, ""},
+		{"invalid rebound env", nil, map[string]string{"ACME_GLOBAL_NO_INPUT": "maybe"}, promptModeState{}, `invalid --no-input value "maybe": strconv.ParseBool: parsing "maybe": invalid syntax`},
+		{"invalid env", nil, map[string]string{"ACME_NON_INTERACTIVE": "maybe"}, promptModeState{}, `invalid ACME_NON_INTERACTIVE value "maybe": strconv.ParseBool: parsing "maybe": invalid syntax`},
+		{"missing answers file", []string{"--answers-file", "missing.yaml"}, nil, promptModeState{}, `read answers file "missing.yaml"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			defer setPromptMode(promptModeState{})
+
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			var mode promptModeState
+			root := Root("acme", "CLI sample application",
+				Execute(func(cmd *cobra.Command, args []string) error {
+					mode = currentPromptMode()
+					return nil
+				}),
+				ConfigureViper("ACME"),
+				ConfigureNonInteractive(),
+			)
+
+			root.SetArgs(tt.args)
+			err := root.Execute()
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				assert.Equal(t, ExitCodeConfig, ExitCode(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, mode)
+		})
+	}
+
+	t.Run("answers file", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		defer setPromptMode(promptModeState{})
+
+		var answer string
+		root := Root("acme", "CLI sample application",
+			Execute(func(cmd *cobra.Command, args []string) (err error) {
+				answer, err = PromptRaw("Enter user ID", WithPromptID("user-id"))
+				return err
+			}),
+			ConfigureViper("ACME"),
+			ConfigureNonInteractive(),
+		)
+
+		root.SetArgs([]string{"--no-input", "--answers-file", answersFile})
+		require.NoError(t, root.Execute())
+		assert.Equal(t, "bob", answer)
+	})
+}
+
+func TestConfigureNonInteractive_ScopedToExecution(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	defer setPromptMode(promptModeState{})
+
+	var failPreRun bool
+	var mode promptModeState
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error {
+			mode = currentPromptMode()
+			return nil
+		}),
+		CommandOptionFunc(func(cmd *cobra.Command) {
+			cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+				if failPreRun {
+					return errors.New("pre-run failed")
+				}
+
+				return nil
+			}
+		}),
+		ConfigureViper("ACME"),
+		ConfigureNonInteractive(),
+	)
+
+	root.SetArgs([]string{"--yes"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, promptModeState{assumeYes: true, envPrefix: "ACME"}, mode)
+	assert.Equal(t, promptModeState{}, currentPromptMode(), "restored once the command executed")
+
+	mode = promptModeState{}
+	require.NoError(t, root.Execute())
+	assert.Equal(t, promptModeState{assumeYes: true, envPrefix: "ACME"}, mode, "effective again on the next execution")
+	assert.Equal(t, promptModeState{}, currentPromptMode())
+
+	failPreRun = true
+	assert.EqualError(t, root.Execute(), "pre-run failed")
+	assert.Equal(t, promptModeState{}, currentPromptMode(), "restored when the execution fails")
+}
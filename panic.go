@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+var annotationPanicAsCommandError = "panic-as-command-error"
+
+// PanicError is the error created from a recovered panic, see [Run] and [Application.SuperviseAndStart].
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// PanicAsCommandError makes panics recovered by [Run] flow as regular errors returned by the
+// command's handler, so they go through [OnCommandError] like any other error. The exit code
+// remains [ExitCodePanic].
+func PanicAsCommandError() CommandOption {
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		setCommandAnnotation(cmd, annotationPanicAsCommandError, true)
+	})
+}
+
+func panicAsCommandError(cmd *cobra.Command) bool {
+	for current := cmd; current != nil; current = current.Parent() {
+		if _, found := getCommandAnnotation(current, annotationPanicAsCommandError); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// recoverPanics recovers from panics of `fn`, by default the panic is logged and the process
+// exits through [Exit] with [ExitCodePanic] so that exit handlers are executed.
+func recoverPanics(fn func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panicErr := newPanicError(recovered, zap.String("command", cmd.CommandPath()))
+				if !panicAsCommandError(cmd) {
+					Exit(ExitCodePanic)
+				}
+
+				err = panicErr
+			}
+		}()
+
+		return fn(cmd, args)
+	}
+}
+
+// newPanicError logs the recovered panic along its stack trace and returns it as a [PanicError].
+func newPanicError(recovered any, fields ...zap.Field) *PanicError {
+	err := &PanicError{Value: recovered, Stack: debug.Stack()}
+
+	zlog.Error("recovered from panic", append(fields, zap.Any("panic", recovered), zap.ByteString("stack", err.Stack))...)
+	zlog.Sync()
+
+	return err
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/shutter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecoverPanics_AsCommandError(t *testing.T) {
+	root := Root("acme", "CLI sample application",
+		Command(func(cmd *cobra.Command, args []string) error {
+			panic("boom")
+		}, "compare", "Compare files"),
+		PanicAsCommandError(),
+	)
+
+	visitAllCommands(root, func(cmd *cobra.Command) {
+		if cmd.RunE != nil {
+			cmd.RunE = recoverPanics(cmd.RunE)
+		}
+	})
+
+	root.SetArgs([]string{"compare"})
+	err := root.Execute()
+
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "boom", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "panic_test.go")
+	assert.Equal(t, ExitCodePanic, ExitCode(err))
+}
+
+type panickingChild struct {
+	*shutter.Shutter
+}
+
+func (c *panickingChild) Run() {
+	panic("child boom")
+}
+
+func TestApplication_PanicAsError(t *testing.T) {
+	app := NewApplication(context.Background(), WithPanicAsError())
+	app.SuperviseAndStart(&panickingChild{shutter.New()})
+
+	err := app.WaitForTermination(zap.NewNop(), 0, time.Second)
+
+	var panicErr *PanicError
+	require.True(t, errors.As(err, &panicErr))
+	assert.Equal(t, "child boom", panicErr.Value)
+	assert.Equal(t, ExitCodePanic, ExitCode(err))
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var SecretFlagAnnotation = "github.com/streamingfast/cli#secret"
+
+// RedactedValue replaces the value of secret flags in dumps and logs.
+var RedactedValue = "<redacted>"
+
+// Secret marks the string flags `names` of the [cobra.Command] as holding secret values. It must
+// be listed after the option defining the flags, a flag not found or not a string flag leads to a
+// panic.
+//
+// Secret values are redacted from any value dump (`--print-config`, `config show`, `config template`)
+// as well as from rebinding debug logs, and their default value is not shown in the help.
+//
+// For each flag, a companion `--<name>-file` flag is defined (persistent if the secret flag is)
+// to read the secret from a file instead, the file content being trimmed of surrounding
+// whitespaces. Like any other flag, it's rebound by [ConfigureViper] so for the `auth` persistent
+// flag of the root command, `{PREFIX}_GLOBAL_AUTH_FILE` can be used too. Providing both the secret
+// and its file is an error.
+func Secret(names ...string) CommandOption {
+	return CommandOptionFunc(func(cmd *cobra.Command) {
+		for _, name := range names {
+			flags := cmd.PersistentFlags()
+			flag := flags.Lookup(name)
+			if flag == nil {
+				flags = cmd.Flags()
+				flag = flags.Lookup(name)
+			}
+
+			if flag == nil {
+				panic(fmt.Errorf("flag %q not defined on command %q, cli.Secret must be listed after the flag definition", name, cmd.Name()))
+			}
+
+			// The default value is redacted by clearing it, which only hides it from the help of string flags
+			if flag.Value.Type() != "string" {
+				panic(fmt.Errorf("flag %q of command %q is a %s flag, cli.Secret only supports string flags", name, cmd.Name(), flag.Value.Type()))
+			}
+
+			addAnnotation(flag, SecretFlagAnnotation, "true")
+			flag.DefValue = ""
+
+			flags.String(name+"-file", "", fmt.Sprintf("File to read the secret value of --%s from, surrounding whitespaces are trimmed", name))
+		}
+
+		addPreRunHook(cmd, preRunPhaseResolve, func(cmd *cobra.Command) error {
+			for _, name := range names {
+				if err := resolveSecretFile(cmd, name); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	})
+}
+
+// IsSecretFlag returns `true` if the flag was marked as secret with [Secret].
+func IsSecretFlag(flag *pflag.Flag) bool {
+	_, found := flag.Annotations[SecretFlagAnnotation]
+	return found
+}
+
+// redactedValue returns `value` or [RedactedValue] if the flag is secret and `value` is not empty.
+func redactedValue(flag *pflag.Flag, value any) any {
+	if !IsSecretFlag(flag) || value == nil || value == "" {
+		return value
+	}
+
+	return RedactedValue
+}
+
+func resolveSecretFile(cmd *cobra.Command, name string) error {
+	flag := cmd.Flags().Lookup(name)
+	fileFlag := cmd.Flags().Lookup(name + "-file")
+	if flag == nil || fileFlag == nil {
+		// Local secret flag of an ancestor, it's not visible to the executed command
+		return nil
+	}
+
+	path := fileFlag.Value.String()
+	if key, found := reboundKey(fileFlag); found {
+		path = ViperFor(cmd).GetString(key)
+	}
+
+	if path == "" {
+		return nil
+	}
+
+	if source, _ := FlagValueSource(cmd, flag); source != ValueSourceDefault {
+		fileSource, _ := FlagValueSource(cmd, fileFlag)
+		return usageErrorf("secret --%s (from %s) and --%s-file (from %s) cannot be provided at the same time", name, source, name, fileSource)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ConfigError(fmt.Errorf("read secret --%s from file: %w", name, err))
+	}
+
+	secret := strings.TrimSpace(string(content))
+	if key, found := reboundKey(flag); found {
+		setReboundValue(ViperFor(cmd), key, secret)
+	} else if err := flag.Value.Set(secret); err != nil {
+		return ConfigError(fmt.Errorf("set secret --%s from file %q: %w", name, path, err))
+	}
+
+	setResolvedSource(flag, ValueSourceFile, path)
+	return nil
+}
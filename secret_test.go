@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth")
+	WriteFile(path, "s3cr3t\n")
+
+	t.Setenv("ACME_GLOBAL_AUTH_FILE", path)
+
+	var auth, authDash string
+	var source ValueSource
+	var dump bytes.Buffer
+
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error {
+			auth = ViperFor(cmd).GetString("global.auth")
+			authDash = ViperFor(cmd).GetString("global-auth")
+			source, _ = FlagValueSource(cmd, cmd.Flags().Lookup("auth"))
+
+			return writeResolvedConfig(cmd, &dump, "json")
+		}),
+		PersistentFlags(func(flags *pflag.FlagSet) { flags.String("auth", "default-token", "Auth token") }),
+		Secret("auth"),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	assert.NotContains(t, root.PersistentFlags().FlagUsages(), "default-token")
+
+	root.SetArgs(nil)
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, "s3cr3t", auth)
+	assert.Equal(t, "s3cr3t", authDash)
+	assert.Equal(t, ValueSourceFile, source)
+	assert.JSONEq(t, `[
+		{"key": "global.auth", "value": "<redacted>", "source": "file", "env": "ACME_GLOBAL_AUTH"},
+		{"key": "global.auth-file", "value": "`+path+`", "source": "env", "env": "ACME_GLOBAL_AUTH_FILE"}
+	]`, dump.String())
+}
+
+func TestSecret_BothProvided(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth")
+	WriteFile(path, "s3cr3t")
+
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+		PersistentFlags(func(flags *pflag.FlagSet) { flags.String("auth", "", "Auth token") }),
+		Secret("auth"),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	root.SetArgs([]string{"--auth", "token", "--auth-file", path})
+	assert.ErrorContains(t, root.Execute(), "secret --auth (from flag) and --auth-file (from flag) cannot be provided at the same time")
+}
+
+func TestSecret_LocalFlagWithSubCommand(t *testing.T) {
+	var executed bool
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+		Flags(func(flags *pflag.FlagSet) { flags.String("auth", "", "Auth token") }),
+		Secret("auth"),
+		Command(func(cmd *cobra.Command, args []string) error {
+			executed = true
+			return nil
+		}, "sub", "Sub command"),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	root.SetArgs([]string{"sub"})
+	require.NoError(t, root.Execute())
+	assert.True(t, executed)
+}
+
+func TestSecret_NonStringFlag(t *testing.T) {
+	assert.PanicsWithError(t, `flag "port" of command "acme" is a int flag, cli.Secret only supports string flags`, func() {
+		Root("acme", "CLI sample application",
+			Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+			Flags(func(flags *pflag.FlagSet) { flags.Int("port", 9000, "Port") }),
+			Secret("port"),
+		)
+	})
+}
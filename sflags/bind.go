@@ -0,0 +1,83 @@
+package sflags
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+)
+
+// Bind fills the struct pointed to by `config` with the configuration value of each field
+// having a `flag` tag, this is the counterpart of `cli.FlagsFromStruct(config)` which defines
+// the flags from the same struct.
+//
+// Values are resolved exactly like the typed getters of this package would, so call it within
+// your command's execution once [cli.ConfigureViper] has rebound the flags to have flags,
+// environment variables, config file and defaults all taken into account.
+//
+// Anonymous struct fields without a `flag` tag are traversed and fields tagged `flag:"-"` are
+// skipped. Named field types are converted from their flag's type, like `type Level int` from an
+// `Int` flag. A field whose flag does not exist on `cmd` (or one of its parent) leads to an
+// [ErrFlagNotExist] error.
+func Bind(cmd *cobra.Command, config any) error {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("bind requires a pointer to a struct, got %T", config)
+	}
+
+	return bindStructValue(cmd, value.Elem())
+}
+
+// MustBind is exactly like [Bind] but prints a message and exit the process with code 1
+// if an error occurs.
+func MustBind(cmd *cobra.Command, config any) {
+	cli.NoError(Bind(cmd, config), "Unable to bind flags into %T", config)
+}
+
+func bindStructValue(cmd *cobra.Command, value reflect.Value) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		tag, hasTag := field.Tag.Lookup("flag")
+		if !hasTag {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				if err := bindStructValue(cmd, value.Field(i)); err != nil {
+					return err
+				}
+			}
+
+			continue
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		name, _, _ := strings.Cut(tag, ",")
+		if !field.IsExported() {
+			return fmt.Errorf("field %s (flag %q) must be exported to be bound", field.Name, name)
+		}
+
+		out, err := Get[any](cmd, name)
+		if err != nil {
+			return fmt.Errorf("read flag %q: %w", name, err)
+		}
+
+		resolved := reflect.ValueOf(out)
+		if !resolved.Type().AssignableTo(field.Type) {
+			// Named types are converted from their underlying type, the kind check rejects the
+			// lossy conversions like int to string
+			if resolved.Kind() != field.Type.Kind() || !resolved.Type().ConvertibleTo(field.Type) {
+				return fmt.Errorf("flag %q of Go type %s cannot be assigned to field %s of type %s", name, resolved.Type(), field.Name, field.Type)
+			}
+
+			resolved = resolved.Convert(field.Type)
+		}
+
+		value.Field(i).Set(resolved)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package sflags
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type commonConfig struct {
+	Name string `flag:"name" default:"acme" usage:"Name"`
+}
+
+type bindConfig struct {
+	commonConfig
+
+	SkipErrors bool              `flag:"skip-errors" usage:"Skip errors"`
+	Timeout    time.Duration     `flag:"timeout" default:"30s" usage:"Timeout"`
+	Verbosity  int               `flag:"verbose,count" usage:"Verbosity"`
+	Retries    uint8             `flag:"retries" usage:"Retries"`
+	Ratio      float32           `flag:"ratio" default:"0.5" usage:"Ratio"`
+	Tags       []string          `flag:"tags" default:"a,b" usage:"Tags"`
+	Paths      []string          `flag:"paths,array" usage:"Paths"`
+	Labels     map[string]string `flag:"labels" usage:"Labels"`
+	Listen     net.IP            `flag:"listen" default:"127.0.0.1" usage:"Listen"`
+	Key        []byte            `flag:"key,base64" usage:"Key"`
+
+	Ignored string
+}
+
+func TestBind(t *testing.T) {
+	var config bindConfig
+	config.Retries = 3
+
+	var bound bindConfig
+	root := cli.Root("acme", "CLI sample application",
+		cli.Execute(func(cmd *cobra.Command, args []string) error {
+			return Bind(cmd, &bound)
+		}),
+		cli.FlagsFromStruct(&config),
+	)
+
+	root.SetArgs([]string{"--skip-errors", "--verbose", "--verbose", "--tags", "c", "--paths", "x,y", "--labels", "k=v", "--key", "aGVsbG8="})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, bindConfig{
+		commonConfig: commonConfig{Name: "acme"},
+		SkipErrors:   true,
+		Timeout:      30 * time.Second,
+		Verbosity:    2,
+		Retries:      3,
+		Ratio:        0.5,
+		Tags:         []string{"c"},
+		Paths:        []string{"x,y"},
+		Labels:       map[string]string{"k": "v"},
+		Listen:       net.ParseIP("127.0.0.1"),
+		Key:          []byte("hello"),
+	}, bound)
+}
+
+type bindViperConfig struct {
+	Name    string        `flag:"name" default:"acme" usage:"Name"`
+	Timeout time.Duration `flag:"timeout" default:"30s" usage:"Timeout"`
+	Tags    []string      `flag:"tags" default:"a,b" usage:"Tags"`
+}
+
+func TestBind_Viper(t *testing.T) {
+	var bound bindViperConfig
+	root := cli.Root("acme", "CLI sample application",
+		cli.Execute(func(cmd *cobra.Command, args []string) error {
+			return Bind(cmd, &bound)
+		}),
+		cli.FlagsFromStruct(&bindViperConfig{}),
+		cli.ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	t.Setenv("ACME_TIMEOUT", "1m")
+
+	root.SetArgs([]string{"--tags", "c"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, bindViperConfig{Name: "acme", Timeout: time.Minute, Tags: []string{"c"}}, bound)
+}
+
+type bindCountConfig struct {
+	Verbosity int `flag:"verbose,count" default:"2" usage:"Verbosity"`
+}
+
+func TestBind_CountDefault(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"default", nil, 2},
+		{"incremented from default", []string{"--verbose"}, 3},
+		{"explicit", []string{"--verbose=5"}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bound bindCountConfig
+			root := cli.Root("acme", "CLI sample application",
+				cli.Execute(func(cmd *cobra.Command, args []string) error {
+					return Bind(cmd, &bound)
+				}),
+				cli.FlagsFromStruct(&bindCountConfig{}),
+			)
+
+			root.SetArgs(tt.args)
+			require.NoError(t, root.Execute())
+
+			assert.Equal(t, tt.expected, bound.Verbosity)
+			assert.Equal(t, "2", root.Flags().Lookup("verbose").DefValue)
+		})
+	}
+}
+
+type level int
+type endpoint string
+type labels []string
+
+type bindNamedConfig struct {
+	Level    level    `flag:"level" default:"2" usage:"Level"`
+	Endpoint endpoint `flag:"endpoint" default:"localhost:9000" usage:"Endpoint"`
+	Labels   labels   `flag:"labels" usage:"Labels"`
+	Skipped  string   `flag:"-"`
+}
+
+func TestBind_NamedTypes(t *testing.T) {
+	var bound bindNamedConfig
+	root := cli.Root("acme", "CLI sample application",
+		cli.Execute(func(cmd *cobra.Command, args []string) error {
+			return Bind(cmd, &bound)
+		}),
+		cli.FlagsFromStruct(&bindNamedConfig{Skipped: "kept"}),
+	)
+
+	root.SetArgs([]string{"--labels", "a,b"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, bindNamedConfig{Level: 2, Endpoint: "localhost:9000", Labels: labels{"a", "b"}}, bound)
+	assert.Nil(t, root.Flags().Lookup("-"))
+}
+
+type bindUnexportedConfig struct {
+	name string `flag:"name" usage:"Name"`
+}
+
+func TestBind_UnexportedField(t *testing.T) {
+	assert.PanicsWithError(t, `field name (flag "name") must be exported to be defined from struct`, func() {
+		cli.Root("acme", "CLI sample application", cli.FlagsFromStruct(&bindUnexportedConfig{}))
+	})
+
+	var bound bindUnexportedConfig
+	root := cli.Root("acme", "CLI sample application",
+		cli.Execute(func(cmd *cobra.Command, args []string) error {
+			return Bind(cmd, &bound)
+		}),
+		cli.Flags(func(flags *pflag.FlagSet) { flags.String("name", "", "Name") }),
+	)
+
+	root.SetArgs(nil)
+	assert.EqualError(t, root.Execute(), `field name (flag "name") must be exported to be bound`)
+}
@@ -0,0 +1,190 @@
+package sflags
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+)
+
+// The converters below turn the raw value resolved by Viper into the flag's Go type for
+// types that Viper has no getter for. The raw value is either a string (environment variable
+// or flat config value) or the structured value decoded from the config file (list or map).
+//
+// Lists are accepted as comma-separated values (`a,b,c`, optionally wrapped in brackets like
+// pflag prints them) and maps as comma-separated `key=value` pairs (`a=1,b=2`).
+
+func sliceFromRaw[T any](parse func(in any) (T, error)) func(raw any) (any, error) {
+	return func(raw any) (any, error) {
+		elements, err := rawElements(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		out := make([]T, len(elements))
+		for i, element := range elements {
+			if out[i], err = parse(element); err != nil {
+				return nil, fmt.Errorf("element #%d: %w", i, err)
+			}
+		}
+
+		return out, nil
+	}
+}
+
+func mapFromRaw[T any](parse func(in any) (T, error)) func(raw any) (any, error) {
+	return func(raw any) (any, error) {
+		entries := map[string]any{}
+
+		if in, ok := raw.(string); ok {
+			pairs, err := splitList(in)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, pair := range pairs {
+				key, value, found := strings.Cut(pair, "=")
+				if !found {
+					return nil, fmt.Errorf("%q must be formatted as key=value", pair)
+				}
+
+				entries[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		} else {
+			var err error
+			if entries, err = cast.ToStringMapE(raw); err != nil {
+				return nil, err
+			}
+		}
+
+		out := make(map[string]T, len(entries))
+		for key, value := range entries {
+			parsed, err := parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", key, err)
+			}
+
+			out[key] = parsed
+		}
+
+		return out, nil
+	}
+}
+
+func scalarFromRaw[T any](parse func(in any) (T, error)) func(raw any) (any, error) {
+	return func(raw any) (any, error) {
+		return parse(raw)
+	}
+}
+
+func rawElements(raw any) ([]any, error) {
+	if in, ok := raw.(string); ok {
+		items, err := splitList(in)
+		if err != nil {
+			return nil, err
+		}
+
+		elements := make([]any, len(items))
+		for i, item := range items {
+			elements[i] = item
+		}
+
+		return elements, nil
+	}
+
+	value := reflect.ValueOf(raw)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return []any{raw}, nil
+	}
+
+	elements := make([]any, value.Len())
+	for i := range elements {
+		elements[i] = value.Index(i).Interface()
+	}
+
+	return elements, nil
+}
+
+// splitList splits comma-separated values, honoring CSV quoting like pflag does.
+func splitList(in string) ([]string, error) {
+	in = strings.TrimSpace(in)
+	in = strings.TrimSuffix(strings.TrimPrefix(in, "["), "]")
+	if in == "" {
+		return []string{}, nil
+	}
+
+	items, err := csv.NewReader(strings.NewReader(in)).Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid list %q: %w", in, err)
+	}
+
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+
+	return items, nil
+}
+
+func parseIP(in any) (net.IP, error) {
+	value, err := cast.ToStringE(in)
+	if err != nil {
+		return nil, err
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(value))
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP %q", value)
+	}
+
+	return ip, nil
+}
+
+func parseIPNet(in any) (net.IPNet, error) {
+	value, err := cast.ToStringE(in)
+	if err != nil {
+		return net.IPNet{}, err
+	}
+
+	_, ipNet, err := net.ParseCIDR(strings.TrimSpace(value))
+	if err != nil {
+		return net.IPNet{}, err
+	}
+
+	return *ipNet, nil
+}
+
+func parseIPMask(in any) (net.IPMask, error) {
+	value, err := cast.ToStringE(in)
+	if err != nil {
+		return nil, err
+	}
+
+	mask := pflag.ParseIPv4Mask(strings.TrimSpace(value))
+	if mask == nil {
+		return nil, fmt.Errorf("invalid IPv4 mask %q", value)
+	}
+
+	return mask, nil
+}
+
+func parseBytes(decode func(string) ([]byte, error)) func(in any) ([]byte, error) {
+	return func(in any) ([]byte, error) {
+		value, err := cast.ToStringE(in)
+		if err != nil {
+			return nil, err
+		}
+
+		return decode(strings.TrimSpace(value))
+	}
+}
+
+var (
+	parseBytesHex    = parseBytes(hex.DecodeString)
+	parseBytesBase64 = parseBytes(base64.StdEncoding.DecodeString)
+)
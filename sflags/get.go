@@ -0,0 +1,138 @@
+package sflags
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+)
+
+var ErrViperTypeNotSupported = errors.New("viper type not supported")
+
+var ErrFlagTypeNotSupported = errors.New("flag type not supported")
+
+type ErrFlagNotExist struct {
+	Name string
+}
+
+func (e *ErrFlagNotExist) Error() string {
+	return fmt.Sprintf("flag %q does not exist", e.Name)
+}
+
+// Get returns the configuration value for your CLI. This method version lookup for the
+// flag's name. If the flag is not found, returns an [ErrFlagNotExist] error.
+//
+// The value is read according to the flag's type (`flag.Value.Type()`) and then converted
+// to `T`, it's an error if `T` is not the Go type of the flag (for example `[]string` for
+// both `StringSlice` and `StringArray` flags).
+//
+// If the flag is found and [cli.ConfigureViper] was used and rebound a key for it, delegate to
+// the command's viper instance (see [cli.ViperFor]). Types for which Viper has no getter (IP,
+// bytes, most slices and maps) are converted from the raw value: lists are accepted as YAML/JSON
+// lists or comma-separated values (`a,b`) and maps as objects or comma-separated `key=value`
+// pairs (`a=1,b=2`), which is what environment variables are usually made of.
+//
+// Otherwise if Viper was not configured, delegates to `cmd.Flags().Get<Type>(name)`.
+//
+// See [cli.ConfigureViper] for key rebinding rules.
+func Get[T any](cmd *cobra.Command, name string) (out T, err error) {
+	out, _, err = get[T](cmd, name)
+	return
+}
+
+// MustGet is exactly like [Get] but prints a message and exit the process with code 1 if
+// the flag does not exist or if its value cannot be retrieved.
+func MustGet[T any](cmd *cobra.Command, name string) T {
+	out, _, err := get[T](cmd, name)
+	cli.NoError(err, "Unable to get flag %q", name)
+
+	return out
+}
+
+// MustGetProvided is exactly like [MustGet] but also return if the key was changed
+// somewhere in the configuration stack.
+func MustGetProvided[T any](cmd *cobra.Command, name string) (T, bool) {
+	out, provided, err := get[T](cmd, name)
+	cli.NoError(err, "Unable to get flag %q", name)
+
+	return out, provided
+}
+
+func get[T any](cmd *cobra.Command, name string) (out T, provided bool, err error) {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		return out, false, &ErrFlagNotExist{Name: name}
+	}
+
+	definition, found := flagTypes[flag.Value.Type()]
+	if !found {
+		return out, false, fmt.Errorf("%w: type %q of flag %q", ErrFlagTypeNotSupported, flag.Value.Type(), name)
+	}
+
+	var value any
+	if reboundKey, found := getReboundKey(flag); found {
+		// The config might be reloaded concurrently, see [cli.Application.WatchConfig]
+		cli.ReadViper(cmd, func(v *viper.Viper) {
+			provided = v.IsSet(reboundKey)
+
+			switch {
+			case definition.fromViper != nil:
+				value = definition.fromViper(v, reboundKey)
+
+			case definition.fromRaw != nil:
+				// Viper gives back the flag's textual representation when it's the source of the
+				// value, in which case we are better served by pflag that already knows how to
+				// parse it.
+				if flag.Changed || !provided {
+					value, err = definition.fromFlags(cmd.Flags(), name)
+					return
+				}
+
+				if value, err = definition.fromRaw(v.Get(reboundKey)); err != nil {
+					err = fmt.Errorf("convert value of flag %q (key %q) to %q: %w", name, reboundKey, definition.name, err)
+				}
+
+			default:
+				err = fmt.Errorf(`%w: unsupported type %q requested via flag %q (key %q)`, ErrViperTypeNotSupported, definition.name, name, reboundKey)
+			}
+		})
+
+		if err != nil {
+			return out, false, err
+		}
+	} else {
+		value, err = definition.fromFlags(cmd.Flags(), name)
+		if err != nil {
+			return out, false, err
+		}
+
+		provided = flag.Changed
+	}
+
+	typed, ok := value.(T)
+	if !ok {
+		return out, false, fmt.Errorf("flag %q of type %q cannot be read as %T", name, definition.name, out)
+	}
+
+	return typed, provided, nil
+}
+
+func getReboundKey(flag *pflag.Flag) (string, bool) {
+	return getAnnotation(flag, cli.ReboundFlagAnnotation)
+}
+
+func getAnnotation(flag *pflag.Flag, key string) (string, bool) {
+	if flag.Annotations == nil {
+		return "", false
+	}
+
+	values := flag.Annotations[key]
+	if len(values) == 0 {
+		return "", false
+	}
+
+	return values[len(values)-1], true
+}
@@ -0,0 +1,107 @@
+package sflags
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/streamingfast/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	for _, withViper := range []bool{false, true} {
+		name := "flags"
+		if withViper {
+			name = "viper"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			opts := []cli.CommandOption{
+				cli.Execute(func(cmd *cobra.Command, args []string) error {
+					out, provided := MustGetProvided[[]string](cmd, "array")
+					assert.Equal(t, []string{"a,b", "c"}, out)
+					assert.True(t, provided)
+
+					count, err := Get[int](cmd, "retries")
+					require.NoError(t, err)
+					assert.Equal(t, 3, count)
+
+					assert.Equal(t, uint8(3), MustGetUint8(cmd, "retries-8"))
+
+					_, err = Get[string](cmd, "retries")
+					assert.ErrorContains(t, err, `flag "retries" of type "Int" cannot be read as string`)
+
+					_, err = Get[string](cmd, "unknown")
+					assert.Equal(t, &ErrFlagNotExist{Name: "unknown"}, err)
+
+					return nil
+				}),
+				cli.Flags(func(flags *pflag.FlagSet) {
+					flags.StringArray("array", nil, "Array")
+					flags.Int("retries", 3, "Retries")
+					flags.Uint8("retries-8", 3, "Retries")
+				}),
+			}
+
+			if withViper {
+				opts = append(opts, cli.ConfigureViperInstance(viper.New(), "ACME"))
+			}
+
+			root := cli.Root("acme", "CLI sample application", opts...)
+			root.SetArgs([]string{"--array", "a,b", "--array", "c"})
+			require.NoError(t, root.Execute())
+		})
+	}
+}
+
+func TestGet_ViperConversion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	cli.WriteFile(path, "%s", cli.Dedent(`
+		durations: [1s, 2m]
+		weights:
+		  a: 1
+		  b: 2
+	`))
+
+	t.Setenv("ACME_PEERS", "10.0.0.1, 10.0.0.2")
+	t.Setenv("ACME_VERBOSE", "2")
+	t.Setenv("ACME_KEY", "68656c6c6f")
+	t.Setenv("ACME_NETWORK", "10.0.0.0/8")
+
+	root := cli.Root("acme", "CLI sample application",
+		cli.Execute(func(cmd *cobra.Command, args []string) error {
+			assert.Equal(t, []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")}, MustGetIPSlice(cmd, "peers"))
+			assert.Equal(t, 2, MustGetCount(cmd, "verbose"))
+			assert.Equal(t, []byte("hello"), MustGetBytesHex(cmd, "key"))
+			network := MustGetIPNet(cmd, "network")
+			assert.Equal(t, "10.0.0.0/8", network.String())
+			assert.Equal(t, []time.Duration{time.Second, 2 * time.Minute}, MustGetDurationSlice(cmd, "durations"))
+			assert.Equal(t, map[string]int64{"a": 1, "b": 2}, MustGetStringToInt64(cmd, "weights"))
+			assert.Equal(t, []bool{true, false}, MustGetBoolSlice(cmd, "toggles"))
+			assert.Equal(t, map[string]int{"x": 1}, MustGetStringToInt(cmd, "limits"))
+
+			return nil
+		}),
+		cli.Flags(func(flags *pflag.FlagSet) {
+			flags.IPSlice("peers", nil, "Peers")
+			flags.Count("verbose", "Verbosity")
+			flags.BytesHex("key", nil, "Key")
+			flags.IPNet("network", net.IPNet{}, "Network")
+			flags.DurationSlice("durations", nil, "Durations")
+			flags.StringToInt64("weights", nil, "Weights")
+			flags.BoolSlice("toggles", []bool{true, false}, "Toggles")
+			flags.StringToInt("limits", nil, "Limits")
+		}),
+		cli.ConfigureViperInstance(viper.New(), "ACME"),
+		cli.ConfigureConfigFile("acme"),
+	)
+
+	root.SetArgs([]string{"--config", path, "--limits", "x=1"})
+	require.NoError(t, root.Execute())
+}
@@ -0,0 +1,21 @@
+package sflags
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/streamingfast/cli"
+)
+
+// Source returns where the value of the flag `name` comes from (flag, environment variable,
+// config file or default) as well as the raw value found there. If the flag is not found,
+// returns an [ErrFlagNotExist] error.
+//
+// See [cli.FlagValueSource] for details.
+func Source(cmd *cobra.Command, name string) (source cli.ValueSource, raw any, err error) {
+	flag := cmd.Flags().Lookup(name)
+	if flag == nil {
+		return "", nil, &ErrFlagNotExist{Name: name}
+	}
+
+	source, raw = cli.FlagValueSource(cmd, flag)
+	return source, raw, nil
+}
@@ -0,0 +1,536 @@
+package sflags
+
+import (
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// The typed functions below are kept for backward compatibility and convenience, they are
+// thin aliases over [Get], [MustGet] and [MustGetProvided].
+
+// GetBool is [Get] for `Bool` flags.
+func GetBool(cmd *cobra.Command, name string) (bool, error) {
+	return Get[bool](cmd, name)
+}
+
+// MustGetBool is [MustGet] for `Bool` flags.
+func MustGetBool(cmd *cobra.Command, name string) bool {
+	return MustGet[bool](cmd, name)
+}
+
+// MustGetBoolProvided is [MustGetProvided] for `Bool` flags.
+func MustGetBoolProvided(cmd *cobra.Command, name string) (bool, bool) {
+	return MustGetProvided[bool](cmd, name)
+}
+
+// GetBoolSlice is [Get] for `BoolSlice` flags.
+func GetBoolSlice(cmd *cobra.Command, name string) ([]bool, error) {
+	return Get[[]bool](cmd, name)
+}
+
+// MustGetBoolSlice is [MustGet] for `BoolSlice` flags.
+func MustGetBoolSlice(cmd *cobra.Command, name string) []bool {
+	return MustGet[[]bool](cmd, name)
+}
+
+// MustGetBoolSliceProvided is [MustGetProvided] for `BoolSlice` flags.
+func MustGetBoolSliceProvided(cmd *cobra.Command, name string) ([]bool, bool) {
+	return MustGetProvided[[]bool](cmd, name)
+}
+
+// GetBytesBase64 is [Get] for `BytesBase64` flags.
+func GetBytesBase64(cmd *cobra.Command, name string) ([]byte, error) {
+	return Get[[]byte](cmd, name)
+}
+
+// MustGetBytesBase64 is [MustGet] for `BytesBase64` flags.
+func MustGetBytesBase64(cmd *cobra.Command, name string) []byte {
+	return MustGet[[]byte](cmd, name)
+}
+
+// MustGetBytesBase64Provided is [MustGetProvided] for `BytesBase64` flags.
+func MustGetBytesBase64Provided(cmd *cobra.Command, name string) ([]byte, bool) {
+	return MustGetProvided[[]byte](cmd, name)
+}
+
+// GetBytesHex is [Get] for `BytesHex` flags.
+func GetBytesHex(cmd *cobra.Command, name string) ([]byte, error) {
+	return Get[[]byte](cmd, name)
+}
+
+// MustGetBytesHex is [MustGet] for `BytesHex` flags.
+func MustGetBytesHex(cmd *cobra.Command, name string) []byte {
+	return MustGet[[]byte](cmd, name)
+}
+
+// MustGetBytesHexProvided is [MustGetProvided] for `BytesHex` flags.
+func MustGetBytesHexProvided(cmd *cobra.Command, name string) ([]byte, bool) {
+	return MustGetProvided[[]byte](cmd, name)
+}
+
+// GetCount is [Get] for `Count` flags.
+func GetCount(cmd *cobra.Command, name string) (int, error) {
+	return Get[int](cmd, name)
+}
+
+// MustGetCount is [MustGet] for `Count` flags.
+func MustGetCount(cmd *cobra.Command, name string) int {
+	return MustGet[int](cmd, name)
+}
+
+// MustGetCountProvided is [MustGetProvided] for `Count` flags.
+func MustGetCountProvided(cmd *cobra.Command, name string) (int, bool) {
+	return MustGetProvided[int](cmd, name)
+}
+
+// GetDuration is [Get] for `Duration` flags.
+func GetDuration(cmd *cobra.Command, name string) (time.Duration, error) {
+	return Get[time.Duration](cmd, name)
+}
+
+// MustGetDuration is [MustGet] for `Duration` flags.
+func MustGetDuration(cmd *cobra.Command, name string) time.Duration {
+	return MustGet[time.Duration](cmd, name)
+}
+
+// MustGetDurationProvided is [MustGetProvided] for `Duration` flags.
+func MustGetDurationProvided(cmd *cobra.Command, name string) (time.Duration, bool) {
+	return MustGetProvided[time.Duration](cmd, name)
+}
+
+// GetDurationSlice is [Get] for `DurationSlice` flags.
+func GetDurationSlice(cmd *cobra.Command, name string) ([]time.Duration, error) {
+	return Get[[]time.Duration](cmd, name)
+}
+
+// MustGetDurationSlice is [MustGet] for `DurationSlice` flags.
+func MustGetDurationSlice(cmd *cobra.Command, name string) []time.Duration {
+	return MustGet[[]time.Duration](cmd, name)
+}
+
+// MustGetDurationSliceProvided is [MustGetProvided] for `DurationSlice` flags.
+func MustGetDurationSliceProvided(cmd *cobra.Command, name string) ([]time.Duration, bool) {
+	return MustGetProvided[[]time.Duration](cmd, name)
+}
+
+// GetFloat32 is [Get] for `Float32` flags.
+func GetFloat32(cmd *cobra.Command, name string) (float32, error) {
+	return Get[float32](cmd, name)
+}
+
+// MustGetFloat32 is [MustGet] for `Float32` flags.
+func MustGetFloat32(cmd *cobra.Command, name string) float32 {
+	return MustGet[float32](cmd, name)
+}
+
+// MustGetFloat32Provided is [MustGetProvided] for `Float32` flags.
+func MustGetFloat32Provided(cmd *cobra.Command, name string) (float32, bool) {
+	return MustGetProvided[float32](cmd, name)
+}
+
+// GetFloat32Slice is [Get] for `Float32Slice` flags.
+func GetFloat32Slice(cmd *cobra.Command, name string) ([]float32, error) {
+	return Get[[]float32](cmd, name)
+}
+
+// MustGetFloat32Slice is [MustGet] for `Float32Slice` flags.
+func MustGetFloat32Slice(cmd *cobra.Command, name string) []float32 {
+	return MustGet[[]float32](cmd, name)
+}
+
+// MustGetFloat32SliceProvided is [MustGetProvided] for `Float32Slice` flags.
+func MustGetFloat32SliceProvided(cmd *cobra.Command, name string) ([]float32, bool) {
+	return MustGetProvided[[]float32](cmd, name)
+}
+
+// GetFloat64 is [Get] for `Float64` flags.
+func GetFloat64(cmd *cobra.Command, name string) (float64, error) {
+	return Get[float64](cmd, name)
+}
+
+// MustGetFloat64 is [MustGet] for `Float64` flags.
+func MustGetFloat64(cmd *cobra.Command, name string) float64 {
+	return MustGet[float64](cmd, name)
+}
+
+// MustGetFloat64Provided is [MustGetProvided] for `Float64` flags.
+func MustGetFloat64Provided(cmd *cobra.Command, name string) (float64, bool) {
+	return MustGetProvided[float64](cmd, name)
+}
+
+// GetFloat64Slice is [Get] for `Float64Slice` flags.
+func GetFloat64Slice(cmd *cobra.Command, name string) ([]float64, error) {
+	return Get[[]float64](cmd, name)
+}
+
+// MustGetFloat64Slice is [MustGet] for `Float64Slice` flags.
+func MustGetFloat64Slice(cmd *cobra.Command, name string) []float64 {
+	return MustGet[[]float64](cmd, name)
+}
+
+// MustGetFloat64SliceProvided is [MustGetProvided] for `Float64Slice` flags.
+func MustGetFloat64SliceProvided(cmd *cobra.Command, name string) ([]float64, bool) {
+	return MustGetProvided[[]float64](cmd, name)
+}
+
+// GetIP is [Get] for `IP` flags.
+func GetIP(cmd *cobra.Command, name string) (net.IP, error) {
+	return Get[net.IP](cmd, name)
+}
+
+// MustGetIP is [MustGet] for `IP` flags.
+func MustGetIP(cmd *cobra.Command, name string) net.IP {
+	return MustGet[net.IP](cmd, name)
+}
+
+// MustGetIPProvided is [MustGetProvided] for `IP` flags.
+func MustGetIPProvided(cmd *cobra.Command, name string) (net.IP, bool) {
+	return MustGetProvided[net.IP](cmd, name)
+}
+
+// GetIPNet is [Get] for `IPNet` flags.
+func GetIPNet(cmd *cobra.Command, name string) (net.IPNet, error) {
+	return Get[net.IPNet](cmd, name)
+}
+
+// MustGetIPNet is [MustGet] for `IPNet` flags.
+func MustGetIPNet(cmd *cobra.Command, name string) net.IPNet {
+	return MustGet[net.IPNet](cmd, name)
+}
+
+// MustGetIPNetProvided is [MustGetProvided] for `IPNet` flags.
+func MustGetIPNetProvided(cmd *cobra.Command, name string) (net.IPNet, bool) {
+	return MustGetProvided[net.IPNet](cmd, name)
+}
+
+// GetIPSlice is [Get] for `IPSlice` flags.
+func GetIPSlice(cmd *cobra.Command, name string) ([]net.IP, error) {
+	return Get[[]net.IP](cmd, name)
+}
+
+// MustGetIPSlice is [MustGet] for `IPSlice` flags.
+func MustGetIPSlice(cmd *cobra.Command, name string) []net.IP {
+	return MustGet[[]net.IP](cmd, name)
+}
+
+// MustGetIPSliceProvided is [MustGetProvided] for `IPSlice` flags.
+func MustGetIPSliceProvided(cmd *cobra.Command, name string) ([]net.IP, bool) {
+	return MustGetProvided[[]net.IP](cmd, name)
+}
+
+// GetIPv4Mask is [Get] for `IPv4Mask` flags.
+func GetIPv4Mask(cmd *cobra.Command, name string) (net.IPMask, error) {
+	return Get[net.IPMask](cmd, name)
+}
+
+// MustGetIPv4Mask is [MustGet] for `IPv4Mask` flags.
+func MustGetIPv4Mask(cmd *cobra.Command, name string) net.IPMask {
+	return MustGet[net.IPMask](cmd, name)
+}
+
+// MustGetIPv4MaskProvided is [MustGetProvided] for `IPv4Mask` flags.
+func MustGetIPv4MaskProvided(cmd *cobra.Command, name string) (net.IPMask, bool) {
+	return MustGetProvided[net.IPMask](cmd, name)
+}
+
+// GetInt is [Get] for `Int` flags.
+func GetInt(cmd *cobra.Command, name string) (int, error) {
+	return Get[int](cmd, name)
+}
+
+// MustGetInt is [MustGet] for `Int` flags.
+func MustGetInt(cmd *cobra.Command, name string) int {
+	return MustGet[int](cmd, name)
+}
+
+// MustGetIntProvided is [MustGetProvided] for `Int` flags.
+func MustGetIntProvided(cmd *cobra.Command, name string) (int, bool) {
+	return MustGetProvided[int](cmd, name)
+}
+
+// GetInt16 is [Get] for `Int16` flags.
+func GetInt16(cmd *cobra.Command, name string) (int16, error) {
+	return Get[int16](cmd, name)
+}
+
+// MustGetInt16 is [MustGet] for `Int16` flags.
+func MustGetInt16(cmd *cobra.Command, name string) int16 {
+	return MustGet[int16](cmd, name)
+}
+
+// MustGetInt16Provided is [MustGetProvided] for `Int16` flags.
+func MustGetInt16Provided(cmd *cobra.Command, name string) (int16, bool) {
+	return MustGetProvided[int16](cmd, name)
+}
+
+// GetInt32 is [Get] for `Int32` flags.
+func GetInt32(cmd *cobra.Command, name string) (int32, error) {
+	return Get[int32](cmd, name)
+}
+
+// MustGetInt32 is [MustGet] for `Int32` flags.
+func MustGetInt32(cmd *cobra.Command, name string) int32 {
+	return MustGet[int32](cmd, name)
+}
+
+// MustGetInt32Provided is [MustGetProvided] for `Int32` flags.
+func MustGetInt32Provided(cmd *cobra.Command, name string) (int32, bool) {
+	return MustGetProvided[int32](cmd, name)
+}
+
+// GetInt32Slice is [Get] for `Int32Slice` flags.
+func GetInt32Slice(cmd *cobra.Command, name string) ([]int32, error) {
+	return Get[[]int32](cmd, name)
+}
+
+// MustGetInt32Slice is [MustGet] for `Int32Slice` flags.
+func MustGetInt32Slice(cmd *cobra.Command, name string) []int32 {
+	return MustGet[[]int32](cmd, name)
+}
+
+// MustGetInt32SliceProvided is [MustGetProvided] for `Int32Slice` flags.
+func MustGetInt32SliceProvided(cmd *cobra.Command, name string) ([]int32, bool) {
+	return MustGetProvided[[]int32](cmd, name)
+}
+
+// GetInt64 is [Get] for `Int64` flags.
+func GetInt64(cmd *cobra.Command, name string) (int64, error) {
+	return Get[int64](cmd, name)
+}
+
+// MustGetInt64 is [MustGet] for `Int64` flags.
+func MustGetInt64(cmd *cobra.Command, name string) int64 {
+	return MustGet[int64](cmd, name)
+}
+
+// MustGetInt64Provided is [MustGetProvided] for `Int64` flags.
+func MustGetInt64Provided(cmd *cobra.Command, name string) (int64, bool) {
+	return MustGetProvided[int64](cmd, name)
+}
+
+// GetInt64Slice is [Get] for `Int64Slice` flags.
+func GetInt64Slice(cmd *cobra.Command, name string) ([]int64, error) {
+	return Get[[]int64](cmd, name)
+}
+
+// MustGetInt64Slice is [MustGet] for `Int64Slice` flags.
+func MustGetInt64Slice(cmd *cobra.Command, name string) []int64 {
+	return MustGet[[]int64](cmd, name)
+}
+
+// MustGetInt64SliceProvided is [MustGetProvided] for `Int64Slice` flags.
+func MustGetInt64SliceProvided(cmd *cobra.Command, name string) ([]int64, bool) {
+	return MustGetProvided[[]int64](cmd, name)
+}
+
+// GetInt8 is [Get] for `Int8` flags.
+func GetInt8(cmd *cobra.Command, name string) (int8, error) {
+	return Get[int8](cmd, name)
+}
+
+// MustGetInt8 is [MustGet] for `Int8` flags.
+func MustGetInt8(cmd *cobra.Command, name string) int8 {
+	return MustGet[int8](cmd, name)
+}
+
+// MustGetInt8Provided is [MustGetProvided] for `Int8` flags.
+func MustGetInt8Provided(cmd *cobra.Command, name string) (int8, bool) {
+	return MustGetProvided[int8](cmd, name)
+}
+
+// GetIntSlice is [Get] for `IntSlice` flags.
+func GetIntSlice(cmd *cobra.Command, name string) ([]int, error) {
+	return Get[[]int](cmd, name)
+}
+
+// MustGetIntSlice is [MustGet] for `IntSlice` flags.
+func MustGetIntSlice(cmd *cobra.Command, name string) []int {
+	return MustGet[[]int](cmd, name)
+}
+
+// MustGetIntSliceProvided is [MustGetProvided] for `IntSlice` flags.
+func MustGetIntSliceProvided(cmd *cobra.Command, name string) ([]int, bool) {
+	return MustGetProvided[[]int](cmd, name)
+}
+
+// GetString is [Get] for `String` flags.
+func GetString(cmd *cobra.Command, name string) (string, error) {
+	return Get[string](cmd, name)
+}
+
+// MustGetString is [MustGet] for `String` flags.
+func MustGetString(cmd *cobra.Command, name string) string {
+	return MustGet[string](cmd, name)
+}
+
+// MustGetStringProvided is [MustGetProvided] for `String` flags.
+func MustGetStringProvided(cmd *cobra.Command, name string) (string, bool) {
+	return MustGetProvided[string](cmd, name)
+}
+
+// GetStringArray is [Get] for `StringArray` flags.
+func GetStringArray(cmd *cobra.Command, name string) ([]string, error) {
+	return Get[[]string](cmd, name)
+}
+
+// MustGetStringArray is [MustGet] for `StringArray` flags.
+func MustGetStringArray(cmd *cobra.Command, name string) []string {
+	return MustGet[[]string](cmd, name)
+}
+
+// MustGetStringArrayProvided is [MustGetProvided] for `StringArray` flags.
+func MustGetStringArrayProvided(cmd *cobra.Command, name string) ([]string, bool) {
+	return MustGetProvided[[]string](cmd, name)
+}
+
+// GetStringSlice is [Get] for `StringSlice` flags.
+func GetStringSlice(cmd *cobra.Command, name string) ([]string, error) {
+	return Get[[]string](cmd, name)
+}
+
+// MustGetStringSlice is [MustGet] for `StringSlice` flags.
+func MustGetStringSlice(cmd *cobra.Command, name string) []string {
+	return MustGet[[]string](cmd, name)
+}
+
+// MustGetStringSliceProvided is [MustGetProvided] for `StringSlice` flags.
+func MustGetStringSliceProvided(cmd *cobra.Command, name string) ([]string, bool) {
+	return MustGetProvided[[]string](cmd, name)
+}
+
+// GetStringToInt is [Get] for `StringToInt` flags.
+func GetStringToInt(cmd *cobra.Command, name string) (map[string]int, error) {
+	return Get[map[string]int](cmd, name)
+}
+
+// MustGetStringToInt is [MustGet] for `StringToInt` flags.
+func MustGetStringToInt(cmd *cobra.Command, name string) map[string]int {
+	return MustGet[map[string]int](cmd, name)
+}
+
+// MustGetStringToIntProvided is [MustGetProvided] for `StringToInt` flags.
+func MustGetStringToIntProvided(cmd *cobra.Command, name string) (map[string]int, bool) {
+	return MustGetProvided[map[string]int](cmd, name)
+}
+
+// GetStringToInt64 is [Get] for `StringToInt64` flags.
+func GetStringToInt64(cmd *cobra.Command, name string) (map[string]int64, error) {
+	return Get[map[string]int64](cmd, name)
+}
+
+// MustGetStringToInt64 is [MustGet] for `StringToInt64` flags.
+func MustGetStringToInt64(cmd *cobra.Command, name string) map[string]int64 {
+	return MustGet[map[string]int64](cmd, name)
+}
+
+// MustGetStringToInt64Provided is [MustGetProvided] for `StringToInt64` flags.
+func MustGetStringToInt64Provided(cmd *cobra.Command, name string) (map[string]int64, bool) {
+	return MustGetProvided[map[string]int64](cmd, name)
+}
+
+// GetStringToString is [Get] for `StringToString` flags.
+func GetStringToString(cmd *cobra.Command, name string) (map[string]string, error) {
+	return Get[map[string]string](cmd, name)
+}
+
+// MustGetStringToString is [MustGet] for `StringToString` flags.
+func MustGetStringToString(cmd *cobra.Command, name string) map[string]string {
+	return MustGet[map[string]string](cmd, name)
+}
+
+// MustGetStringToStringProvided is [MustGetProvided] for `StringToString` flags.
+func MustGetStringToStringProvided(cmd *cobra.Command, name string) (map[string]string, bool) {
+	return MustGetProvided[map[string]string](cmd, name)
+}
+
+// GetUint is [Get] for `Uint` flags.
+func GetUint(cmd *cobra.Command, name string) (uint, error) {
+	return Get[uint](cmd, name)
+}
+
+// MustGetUint is [MustGet] for `Uint` flags.
+func MustGetUint(cmd *cobra.Command, name string) uint {
+	return MustGet[uint](cmd, name)
+}
+
+// MustGetUintProvided is [MustGetProvided] for `Uint` flags.
+func MustGetUintProvided(cmd *cobra.Command, name string) (uint, bool) {
+	return MustGetProvided[uint](cmd, name)
+}
+
+// GetUint16 is [Get] for `Uint16` flags.
+func GetUint16(cmd *cobra.Command, name string) (uint16, error) {
+	return Get[uint16](cmd, name)
+}
+
+// MustGetUint16 is [MustGet] for `Uint16` flags.
+func MustGetUint16(cmd *cobra.Command, name string) uint16 {
+	return MustGet[uint16](cmd, name)
+}
+
+// MustGetUint16Provided is [MustGetProvided] for `Uint16` flags.
+func MustGetUint16Provided(cmd *cobra.Command, name string) (uint16, bool) {
+	return MustGetProvided[uint16](cmd, name)
+}
+
+// GetUint32 is [Get] for `Uint32` flags.
+func GetUint32(cmd *cobra.Command, name string) (uint32, error) {
+	return Get[uint32](cmd, name)
+}
+
+// MustGetUint32 is [MustGet] for `Uint32` flags.
+func MustGetUint32(cmd *cobra.Command, name string) uint32 {
+	return MustGet[uint32](cmd, name)
+}
+
+// MustGetUint32Provided is [MustGetProvided] for `Uint32` flags.
+func MustGetUint32Provided(cmd *cobra.Command, name string) (uint32, bool) {
+	return MustGetProvided[uint32](cmd, name)
+}
+
+// GetUint64 is [Get] for `Uint64` flags.
+func GetUint64(cmd *cobra.Command, name string) (uint64, error) {
+	return Get[uint64](cmd, name)
+}
+
+// MustGetUint64 is [MustGet] for `Uint64` flags.
+func MustGetUint64(cmd *cobra.Command, name string) uint64 {
+	return MustGet[uint64](cmd, name)
+}
+
+// MustGetUint64Provided is [MustGetProvided] for `Uint64` flags.
+func MustGetUint64Provided(cmd *cobra.Command, name string) (uint64, bool) {
+	return MustGetProvided[uint64](cmd, name)
+}
+
+// GetUint8 is [Get] for `Uint8` flags.
+func GetUint8(cmd *cobra.Command, name string) (uint8, error) {
+	return Get[uint8](cmd, name)
+}
+
+// MustGetUint8 is [MustGet] for `Uint8` flags.
+func MustGetUint8(cmd *cobra.Command, name string) uint8 {
+	return MustGet[uint8](cmd, name)
+}
+
+// MustGetUint8Provided is [MustGetProvided] for `Uint8` flags.
+func MustGetUint8Provided(cmd *cobra.Command, name string) (uint8, bool) {
+	return MustGetProvided[uint8](cmd, name)
+}
+
+// GetUintSlice is [Get] for `UintSlice` flags.
+func GetUintSlice(cmd *cobra.Command, name string) ([]uint, error) {
+	return Get[[]uint](cmd, name)
+}
+
+// MustGetUintSlice is [MustGet] for `UintSlice` flags.
+func MustGetUintSlice(cmd *cobra.Command, name string) []uint {
+	return MustGet[[]uint](cmd, name)
+}
+
+// MustGetUintSliceProvided is [MustGetProvided] for `UintSlice` flags.
+func MustGetUintSliceProvided(cmd *cobra.Command, name string) ([]uint, bool) {
+	return MustGetProvided[[]uint](cmd, name)
+}
@@ -0,0 +1,70 @@
+package sflags
+
+import (
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type flagType struct {
+	// name is the type's name as used by the `Get<Name>` functions of pflag.
+	name string
+
+	fromFlags func(flags *pflag.FlagSet, name string) (any, error)
+
+	// fromViper is nil when Viper has no getter for the type, in which case fromRaw
+	// converts the raw value resolved by Viper.
+	fromViper func(v *viper.Viper, key string) any
+	fromRaw   func(raw any) (any, error)
+}
+
+// flagTypes is keyed by the value returned by the flag's `Value.Type()`.
+var flagTypes = map[string]flagType{
+	"boolSlice":      {"BoolSlice", fromFlags((*pflag.FlagSet).GetBoolSlice), nil, sliceFromRaw(cast.ToBoolE)},
+	"uint8":          {"Uint8", fromFlags((*pflag.FlagSet).GetUint8), func(v *viper.Viper, key string) any { return uint8(v.GetUint16(key)) }, nil},
+	"stringSlice":    {"StringSlice", fromFlags((*pflag.FlagSet).GetStringSlice), fromViper((*viper.Viper).GetStringSlice), nil},
+	"ipSlice":        {"IPSlice", fromFlags((*pflag.FlagSet).GetIPSlice), nil, sliceFromRaw(parseIP)},
+	"stringToString": {"StringToString", fromFlags((*pflag.FlagSet).GetStringToString), fromViper((*viper.Viper).GetStringMapString), nil},
+	"float64":        {"Float64", fromFlags((*pflag.FlagSet).GetFloat64), fromViper((*viper.Viper).GetFloat64), nil},
+	"uint32":         {"Uint32", fromFlags((*pflag.FlagSet).GetUint32), fromViper((*viper.Viper).GetUint32), nil},
+	"durationSlice":  {"DurationSlice", fromFlags((*pflag.FlagSet).GetDurationSlice), nil, sliceFromRaw(cast.ToDurationE)},
+	"uint16":         {"Uint16", fromFlags((*pflag.FlagSet).GetUint16), fromViper((*viper.Viper).GetUint16), nil},
+	"float32Slice":   {"Float32Slice", fromFlags((*pflag.FlagSet).GetFloat32Slice), nil, sliceFromRaw(cast.ToFloat32E)},
+	"duration":       {"Duration", fromFlags((*pflag.FlagSet).GetDuration), fromViper((*viper.Viper).GetDuration), nil},
+	"int64":          {"Int64", fromFlags((*pflag.FlagSet).GetInt64), fromViper((*viper.Viper).GetInt64), nil},
+	"uintSlice":      {"UintSlice", fromFlags((*pflag.FlagSet).GetUintSlice), nil, sliceFromRaw(cast.ToUintE)},
+	"bool":           {"Bool", fromFlags((*pflag.FlagSet).GetBool), fromViper((*viper.Viper).GetBool), nil},
+	"int32Slice":     {"Int32Slice", fromFlags((*pflag.FlagSet).GetInt32Slice), nil, sliceFromRaw(cast.ToInt32E)},
+	"int32":          {"Int32", fromFlags((*pflag.FlagSet).GetInt32), fromViper((*viper.Viper).GetInt32), nil},
+	"stringToInt":    {"StringToInt", fromFlags((*pflag.FlagSet).GetStringToInt), nil, mapFromRaw(cast.ToIntE)},
+	"int16":          {"Int16", fromFlags((*pflag.FlagSet).GetInt16), func(v *viper.Viper, key string) any { return int16(v.GetInt32(key)) }, nil},
+	"ip":             {"IP", fromFlags((*pflag.FlagSet).GetIP), nil, scalarFromRaw(parseIP)},
+	"ipNet":          {"IPNet", fromFlags((*pflag.FlagSet).GetIPNet), nil, scalarFromRaw(parseIPNet)},
+	"uint64":         {"Uint64", fromFlags((*pflag.FlagSet).GetUint64), fromViper((*viper.Viper).GetUint64), nil},
+	"stringToInt64":  {"StringToInt64", fromFlags((*pflag.FlagSet).GetStringToInt64), nil, mapFromRaw(cast.ToInt64E)},
+	"float32":        {"Float32", fromFlags((*pflag.FlagSet).GetFloat32), func(v *viper.Viper, key string) any { return float32(v.GetFloat64(key)) }, nil},
+	"ipMask":         {"IPv4Mask", fromFlags((*pflag.FlagSet).GetIPv4Mask), nil, scalarFromRaw(parseIPMask)},
+	"count":          {"Count", fromFlags((*pflag.FlagSet).GetCount), nil, scalarFromRaw(cast.ToIntE)},
+	"int":            {"Int", fromFlags((*pflag.FlagSet).GetInt), fromViper((*viper.Viper).GetInt), nil},
+	"uint":           {"Uint", fromFlags((*pflag.FlagSet).GetUint), fromViper((*viper.Viper).GetUint), nil},
+	"float64Slice":   {"Float64Slice", fromFlags((*pflag.FlagSet).GetFloat64Slice), nil, sliceFromRaw(cast.ToFloat64E)},
+	"int8":           {"Int8", fromFlags((*pflag.FlagSet).GetInt8), func(v *viper.Viper, key string) any { return int8(v.GetInt32(key)) }, nil},
+	"bytesHex":       {"BytesHex", fromFlags((*pflag.FlagSet).GetBytesHex), nil, scalarFromRaw(parseBytesHex)},
+	"bytesBase64":    {"BytesBase64", fromFlags((*pflag.FlagSet).GetBytesBase64), nil, scalarFromRaw(parseBytesBase64)},
+	"intSlice":       {"IntSlice", fromFlags((*pflag.FlagSet).GetIntSlice), fromViper((*viper.Viper).GetIntSlice), nil},
+	"string":         {"String", fromFlags((*pflag.FlagSet).GetString), fromViper((*viper.Viper).GetString), nil},
+	"stringArray":    {"StringArray", fromFlags((*pflag.FlagSet).GetStringArray), fromViper((*viper.Viper).GetStringSlice), nil},
+	"int64Slice":     {"Int64Slice", fromFlags((*pflag.FlagSet).GetInt64Slice), nil, sliceFromRaw(cast.ToInt64E)},
+}
+
+func fromFlags[T any](get func(flags *pflag.FlagSet, name string) (T, error)) func(flags *pflag.FlagSet, name string) (any, error) {
+	return func(flags *pflag.FlagSet, name string) (any, error) {
+		return get(flags, name)
+	}
+}
+
+func fromViper[T any](get func(v *viper.Viper, key string) T) func(v *viper.Viper, key string) any {
+	return func(v *viper.Viper, key string) any {
+		return get(v, key)
+	}
+}
@@ -1,16 +1,21 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/spf13/cobra"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
+var annotationSignalOptions = "signal-options"
+
 // SetupSignalHandler registers a signal handler for SIGINT and SIGTERM and returns a channel to receive
 // determines if the service has been signaled to shutdown gracefully as well as 2 atomic booleans.
 //
@@ -21,8 +26,9 @@ import (
 // the `unreadyPeriodDelay` parameter details below for more information.
 //
 // The second one `waitedFullDelay` determines if the service has waited the full delay period before notifying
-// the channel. This will be `true` unless `unreadyPeriodDelay > 0` and that `Ctrl-C` was pressed 4 times or more
-// which forces a kill `os.Exit(1)`!
+// the channel. This will be `true` unless `unreadyPeriodDelay > 0` and that a second termination signal was
+// received before the delay expired, the channel being notified right away in this case. Pressing `Ctrl-C`
+// 4 times or more forces a kill through `cli.Exit(cli.ExitCodeInterrupted)`!
 //
 // The parameter `unreadyPeriodDelay` influences when you will be notified of the signal through the channel. If you
 // set it to 0, you will be notified immediately. If you set it to 5 seconds, you will be notified of the signal
@@ -35,58 +41,351 @@ import (
 //
 // This should usually be used for HTTP/gRPC servers, for other types of apps, you can set this to 0 to avoid
 // this needlessly waiting period.
+//
+// See [NewSignalHandler] for a configurable variant.
 func SetupSignalHandler(unreadyPeriodDelay time.Duration, logger *zap.Logger) (receiveOutgoingSignals <-chan os.Signal, hasBeenSignaled, waitedFullDelay *atomic.Bool) {
-	signals := make(chan os.Signal, 10)
-	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	handler := NewSignalHandler(logger, WithUnreadyPeriodDelay(unreadyPeriodDelay))
 
-	seen := 0
-	outgoingSignals := make(chan os.Signal, 10)
+	return handler.Signals, handler.HasBeenSignaled, handler.WaitedFullDelay
+}
 
-	receiveOutgoingSignals = outgoingSignals
-	hasBeenSignaled = atomic.NewBool(false)
-	waitedFullDelay = atomic.NewBool(false)
+// SignalHandler is the signal handler created by [NewSignalHandler], see [SetupSignalHandler] for
+// the semantics of `Signals`, `HasBeenSignaled` and `WaitedFullDelay`.
+type SignalHandler struct {
+	Signals         <-chan os.Signal
+	HasBeenSignaled *atomic.Bool
+	WaitedFullDelay *atomic.Bool
 
-	go func() {
-		for {
-			s := <-signals
-			switch s {
-			case syscall.SIGTERM, syscall.SIGINT:
-				seen++
+	options  signalOptions
+	logger   *zap.Logger
+	notified chan os.Signal
+	incoming <-chan os.Signal
+	outgoing chan os.Signal
+	seen     int
 
-				if seen > 3 {
-					logger.Info("received termination signal 3 times, forcing kill")
-					logger.Sync()
+	stopOnce sync.Once
+	done     chan struct{}
+}
 
-					Exit(1)
-				}
+type SignalOption interface {
+	Apply(opts *signalOptions)
+}
 
-				if !hasBeenSignaled.Load() {
-					hasBeenSignaled.Store(true)
-
-					if unreadyPeriodDelay <= 0 {
-						logger.Info("received termination signal and no unready period delay configured, exiting now")
-						waitedFullDelay.Store(true)
-						outgoingSignals <- s
-						break
-					}
-
-					logger.Info(
-						fmt.Sprintf("received termination signal, waiting for unready period delay %s before notifying listner (Ctrl+C again 3 times to force kill!)", unreadyPeriodDelay),
-						zap.Stringer("signal", s),
-					)
-
-					go time.AfterFunc(unreadyPeriodDelay, func() {
-						waitedFullDelay.Store(true)
-						outgoingSignals <- s
-					})
-					break
-				}
+type signalOptions struct {
+	unreadyPeriodDelay time.Duration
+	terminationSignals []os.Signal
+	forceKillThreshold int
+	handlers           []signalHandlerFunc
+	hasBeenSignaled    *atomic.Bool
+	source             <-chan os.Signal
+	clock              Clock
+	exit               func(code int)
+}
+
+type signalHandlerFunc struct {
+	signal os.Signal
+	handle func(s os.Signal)
+}
+
+type signalOptionFunc func(opts *signalOptions)
+
+func (f signalOptionFunc) Apply(opts *signalOptions) {
+	f(opts)
+}
+
+// WithUnreadyPeriodDelay sets the delay between the first termination signal and the notification
+// of the handler's `Signals` channel, see `unreadyPeriodDelay` on [SetupSignalHandler].
+func WithUnreadyPeriodDelay(delay time.Duration) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.unreadyPeriodDelay = delay })
+}
+
+// WithTerminationSignals sets the signals requesting the termination of the application, SIGINT
+// and SIGTERM by default.
+func WithTerminationSignals(signals ...os.Signal) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.terminationSignals = signals })
+}
+
+// WithForceKillThreshold sets the number of termination signals after which the process is
+// killed right away through `cli.Exit(cli.ExitCodeInterrupted)`, 4 by default. A value of 0 or
+// lower disables the force kill.
+func WithForceKillThreshold(count int) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.forceKillThreshold = count })
+}
+
+// WithSignalHandler registers `handle` to be called when `signal` is received, `signal` must not
+// be one of the termination signals. Handlers run in their own goroutine.
+func WithSignalHandler(signal os.Signal, handle func(s os.Signal)) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) {
+		opts.handlers = append(opts.handlers, signalHandlerFunc{signal, handle})
+	})
+}
+
+// WithReloadHandler registers `reload` to be called when SIGHUP is received, usually to reload
+// the configuration of the application.
+func WithReloadHandler(reload func()) SignalOption {
+	return WithSignalHandler(syscall.SIGHUP, func(_ os.Signal) { reload() })
+}
+
+// WithSignalSource makes the handler receive its signals from `source` instead of registering
+// for them through [signal.Notify], mostly useful to simulate signals in tests.
+func WithSignalSource(source <-chan os.Signal) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.source = source })
+}
+
+// WithClock sets the [Clock] used to wait for the unready period delay, see [FakeClock] to
+// control it in tests.
+func WithClock(clock Clock) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.clock = clock })
+}
+
+// WithExitFunc sets the function called to force kill the process once the force kill threshold
+// is reached, [Exit] by default.
+func WithExitFunc(exit func(code int)) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.exit = exit })
+}
+
+// withSignaledFlag makes the handler flag signal reception in the received `hasBeenSignaled`.
+func withSignaledFlag(hasBeenSignaled *atomic.Bool) SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) { opts.hasBeenSignaled = hasBeenSignaled })
+}
+
+// NewSignalHandler registers a signal handler configured through `opts`, it behaves like
+// [SetupSignalHandler] by default. Call [SignalHandler.Stop] once the application terminates.
+func NewSignalHandler(logger *zap.Logger, opts ...SignalOption) *SignalHandler {
+	options := signalOptions{
+		terminationSignals: []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		forceKillThreshold: 4,
+		clock:              systemClock{},
+		exit:               Exit,
+	}
+
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	if options.hasBeenSignaled == nil {
+		options.hasBeenSignaled = atomic.NewBool(false)
+	}
+
+	outgoing := make(chan os.Signal, 10)
+	handler := &SignalHandler{
+		Signals:         outgoing,
+		HasBeenSignaled: options.hasBeenSignaled,
+		WaitedFullDelay: atomic.NewBool(false),
+
+		options:  options,
+		logger:   logger,
+		incoming: options.source,
+		outgoing: outgoing,
+		done:     make(chan struct{}),
+	}
+
+	if handler.incoming == nil {
+		signals := append([]os.Signal{}, options.terminationSignals...)
+		for _, h := range options.handlers {
+			signals = append(signals, h.signal)
+		}
+
+		handler.notified = make(chan os.Signal, 10)
+		handler.incoming = handler.notified
+		signal.Notify(handler.notified, signals...)
+	}
+
+	go handler.run()
+
+	return handler
+}
+
+// Stop unregisters the handler from the signals it listens to and stops its goroutine.
+func (h *SignalHandler) Stop() {
+	h.stopOnce.Do(func() {
+		if h.notified != nil {
+			signal.Stop(h.notified)
+		}
+
+		close(h.done)
+	})
+}
+
+func (h *SignalHandler) run() {
+	for {
+		select {
+		case <-h.done:
+			return
+		case s, ok := <-h.incoming:
+			if !ok {
+				return
+			}
 
-				logger.Info("received termination signal twice, shutting down now", zap.Stringer("signal", s))
-				outgoingSignals <- s
+			if h.isTerminationSignal(s) {
+				h.onTerminationSignal(s)
+				continue
 			}
+
+			for _, handler := range h.options.handlers {
+				if handler.signal == s {
+					h.logger.Info("received signal, invoking its handler", zap.Stringer("signal", s))
+					go handler.handle(s)
+				}
+			}
+		}
+	}
+}
+
+func (h *SignalHandler) isTerminationSignal(s os.Signal) bool {
+	for _, candidate := range h.options.terminationSignals {
+		if candidate == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *SignalHandler) onTerminationSignal(s os.Signal) {
+	h.seen++
+
+	threshold := h.options.forceKillThreshold
+	if threshold > 0 && h.seen >= threshold {
+		h.logger.Info(fmt.Sprintf("received termination signal %d times, forcing kill", threshold-1))
+		h.logger.Sync()
+
+		h.options.exit(ExitCodeInterrupted)
+		return
+	}
+
+	if !h.HasBeenSignaled.Load() {
+		h.HasBeenSignaled.Store(true)
+
+		if h.options.unreadyPeriodDelay <= 0 {
+			h.logger.Info("received termination signal and no unready period delay configured, exiting now")
+			h.WaitedFullDelay.Store(true)
+			h.notify(s)
+			return
+		}
+
+		forceKillHint := ""
+		if threshold > 0 {
+			forceKillHint = fmt.Sprintf(" (Ctrl+C again %d times to force kill!)", threshold-1)
+		}
+
+		h.logger.Info(
+			fmt.Sprintf("received termination signal, waiting for unready period delay %s before notifying listner%s", h.options.unreadyPeriodDelay, forceKillHint),
+			zap.Stringer("signal", s),
+		)
+
+		h.options.clock.AfterFunc(h.options.unreadyPeriodDelay, func() {
+			h.WaitedFullDelay.Store(true)
+			h.notify(s)
+		})
+		return
+	}
+
+	h.logger.Info("received termination signal twice, shutting down now", zap.Stringer("signal", s))
+	h.notify(s)
+}
+
+// notify delivers `s` to [SignalHandler.Signals] without ever blocking the handler's loop, a
+// listener not draining the channel already received enough notifications to shut down.
+func (h *SignalHandler) notify(s os.Signal) {
+	select {
+	case h.outgoing <- s:
+	default:
+		h.logger.Debug("signals channel full, dropping termination signal notification", zap.Stringer("signal", s))
+	}
+}
+
+// SignalContext returns a copy of `parent` that is cancelled once the [SignalHandler] configured
+// through `opts` notifies a termination signal, so the unready period delay and the force kill
+// threshold are respected. The returned function stops the handler and cancels the context.
+func SignalContext(parent context.Context, logger *zap.Logger, opts ...SignalOption) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	handler := NewSignalHandler(logger, opts...)
+
+	go func() {
+		select {
+		case <-handler.Signals:
+			cancel()
+		case <-ctx.Done():
 		}
 	}()
 
-	return outgoingSignals, hasBeenSignaled, waitedFullDelay
+	return ctx, func() {
+		handler.Stop()
+		cancel()
+	}
+}
+
+// ConfigureSignals configures the signal handler backing the context of the commands executed
+// by [Run], see [ExecuteContext]. It must be used on the root command.
+//
+// When the command runs an [Application] created from the command's context, [Application.WaitForTermination]
+// shares the context's signal handler, its unready period delay and options taking precedence
+// over the ones configured here.
+func ConfigureSignals(opts ...SignalOption) CommandOption {
+	return CommandOptionFunc(func(root *cobra.Command) {
+		setCommandAnnotation(root, annotationSignalOptions, opts)
+	})
+}
+
+// runContext returns the context given to the commands executed by [Run], cancelled on the
+// first termination signal as configured by [ConfigureSignals].
+func runContext(root *cobra.Command) (context.Context, func()) {
+	var opts []SignalOption
+	if value, found := getCommandAnnotation(root, annotationSignalOptions); found {
+		opts = value.([]SignalOption)
+	}
+
+	ctx := &lazySignalContext{Context: context.Background(), opts: opts, signaled: atomic.NewBool(false)}
+	return ctx, ctx.stop
+}
+
+// lazySignalContext registers the signal handler of its [SignalContext] only once it's waited
+// on, commands not using their context keep the default behavior of being killed on Ctrl-C.
+type lazySignalContext struct {
+	context.Context
+	opts     []SignalOption
+	signaled *atomic.Bool
+
+	once   sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// start registers the signal handler configured with `extra` on top of the context's options,
+// it returns false if the handler was already registered, `extra` being ignored then.
+func (c *lazySignalContext) start(logger *zap.Logger, extra ...SignalOption) (started bool) {
+	c.once.Do(func() {
+		opts := append([]SignalOption{withSignaledFlag(c.signaled)}, c.opts...)
+		c.ctx, c.cancel = SignalContext(c.Context, logger, append(opts, extra...)...)
+		started = true
+	})
+
+	return started
+}
+
+// lazySignalContextKey retrieves the [lazySignalContext] a context is derived from.
+type lazySignalContextKey struct{}
+
+func (c *lazySignalContext) Value(key any) any {
+	if key == (lazySignalContextKey{}) {
+		return c
+	}
+
+	return c.Context.Value(key)
+}
+
+func (c *lazySignalContext) Done() <-chan struct{} {
+	c.start(zlog)
+	return c.ctx.Done()
+}
+
+func (c *lazySignalContext) Err() error {
+	c.start(zlog)
+	return c.ctx.Err()
+}
+
+func (c *lazySignalContext) stop() {
+	c.once.Do(func() { c.ctx, c.cancel = context.WithCancel(c.Context) })
+	c.cancel()
 }
@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testSignalHandler struct {
+	*SignalHandler
+	source chan os.Signal
+	clock  *FakeClock
+	exits  chan int
+}
+
+func newTestSignalHandler(t *testing.T, opts ...SignalOption) *testSignalHandler {
+	t.Helper()
+
+	source := make(chan os.Signal)
+	clock := NewFakeClock()
+	exits := make(chan int, 10)
+
+	handler := NewSignalHandler(zap.NewNop(), append([]SignalOption{
+		WithSignalSource(source),
+		WithClock(clock),
+		WithExitFunc(func(code int) { exits <- code }),
+	}, opts...)...)
+	t.Cleanup(handler.Stop)
+
+	return &testSignalHandler{handler, source, clock, exits}
+}
+
+// send delivers `s` to the handler, the source being unbuffered, the previous signal is
+// guaranteed to have been processed once this returns.
+func (h *testSignalHandler) send(t *testing.T, s os.Signal) {
+	t.Helper()
+
+	select {
+	case h.source <- s:
+	case <-time.After(time.Second):
+		t.Fatalf("signal %s not received by handler", s)
+	}
+}
+
+// sync waits for the handler to be done with previously sent signals.
+func (h *testSignalHandler) sync(t *testing.T) {
+	t.Helper()
+
+	// A signal that is not handled does nothing, it's only used to wait for the handler's loop
+	h.send(t, syscall.Signal(0))
+}
+
+func (h *testSignalHandler) requireNotified(t *testing.T, expected os.Signal) {
+	t.Helper()
+
+	select {
+	case s := <-h.Signals:
+		assert.Equal(t, expected, s)
+	case <-time.After(time.Second):
+		t.Fatal("handler did not notify its signals channel")
+	}
+}
+
+func (h *testSignalHandler) requireNotNotified(t *testing.T) {
+	t.Helper()
+
+	h.sync(t)
+	select {
+	case s := <-h.Signals:
+		t.Fatalf("handler unexpectedly notified its signals channel with %s", s)
+	default:
+	}
+}
+
+func TestSignalHandler_NoUnreadyPeriodDelay(t *testing.T) {
+	handler := newTestSignalHandler(t)
+
+	assert.False(t, handler.HasBeenSignaled.Load())
+
+	handler.send(t, syscall.SIGTERM)
+	handler.requireNotified(t, syscall.SIGTERM)
+
+	assert.True(t, handler.HasBeenSignaled.Load())
+	assert.True(t, handler.WaitedFullDelay.Load())
+	assert.Equal(t, 0, handler.clock.PendingTimers())
+}
+
+func TestSignalHandler_UnreadyPeriodDelay(t *testing.T) {
+	handler := newTestSignalHandler(t, WithUnreadyPeriodDelay(5*time.Second))
+
+	handler.send(t, syscall.SIGINT)
+	handler.requireNotNotified(t)
+
+	assert.True(t, handler.HasBeenSignaled.Load(), "unready as soon as signaled")
+	assert.False(t, handler.WaitedFullDelay.Load())
+
+	handler.clock.Advance(4 * time.Second)
+	handler.requireNotNotified(t)
+	assert.False(t, handler.WaitedFullDelay.Load())
+
+	handler.clock.Advance(time.Second)
+	handler.requireNotified(t, syscall.SIGINT)
+	assert.True(t, handler.WaitedFullDelay.Load())
+}
+
+func TestSignalHandler_DoubleSignal(t *testing.T) {
+	handler := newTestSignalHandler(t, WithUnreadyPeriodDelay(5*time.Second))
+
+	handler.send(t, syscall.SIGINT)
+	handler.clock.Advance(time.Second)
+	handler.requireNotNotified(t)
+
+	handler.send(t, syscall.SIGTERM)
+	handler.requireNotified(t, syscall.SIGTERM)
+
+	assert.True(t, handler.HasBeenSignaled.Load())
+	assert.False(t, handler.WaitedFullDelay.Load(), "notified before the delay expired")
+	assert.Empty(t, handler.exits)
+}
+
+func TestSignalHandler_ForceKill(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []SignalOption
+		signals      int
+		expectedExit bool
+	}{
+		{"default threshold not reached", nil, 3, false},
+		{"default threshold reached", nil, 4, true},
+		{"custom threshold", []SignalOption{WithForceKillThreshold(2)}, 2, true},
+		{"disabled", []SignalOption{WithForceKillThreshold(0)}, 10, false},
+		{"disabled with notifications not drained", []SignalOption{WithForceKillThreshold(0)}, 30, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := newTestSignalHandler(t, append([]SignalOption{WithUnreadyPeriodDelay(time.Minute)}, tt.opts...)...)
+
+			for i := 0; i < tt.signals; i++ {
+				handler.send(t, syscall.SIGINT)
+			}
+			handler.sync(t)
+
+			if tt.expectedExit {
+				require.Len(t, handler.exits, 1)
+				assert.Equal(t, ExitCodeInterrupted, <-handler.exits)
+			} else {
+				assert.Empty(t, handler.exits)
+			}
+
+			assert.False(t, handler.WaitedFullDelay.Load())
+		})
+	}
+}
+
+func TestSignalHandler_IgnoresOtherSignals(t *testing.T) {
+	handler := newTestSignalHandler(t, WithTerminationSignals(syscall.SIGTERM))
+
+	handler.send(t, syscall.SIGINT)
+	handler.requireNotNotified(t)
+	assert.False(t, handler.HasBeenSignaled.Load())
+
+	handler.send(t, syscall.SIGTERM)
+	handler.requireNotified(t, syscall.SIGTERM)
+}
+
+func TestSignalContext(t *testing.T) {
+	source := make(chan os.Signal)
+	clock := NewFakeClock()
+
+	ctx, cancel := SignalContext(context.Background(), zap.NewNop(), WithSignalSource(source), WithClock(clock), WithUnreadyPeriodDelay(5*time.Second))
+	defer cancel()
+
+	source <- syscall.SIGINT
+	require.Eventually(t, func() bool { return clock.PendingTimers() == 1 }, time.Second, time.Millisecond)
+	assert.NoError(t, ctx.Err(), "cancelled only once the unready period delay expired")
+
+	clock.Advance(5 * time.Second)
+	select {
+	case <-ctx.Done():
+		assert.Equal(t, context.Canceled, ctx.Err())
+	case <-time.After(time.Second):
+		t.Fatal("context not cancelled")
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	source := make(chan os.Signal)
+
+	started := make(chan struct{})
+	root := Root("acme", "CLI sample application",
+		ExecuteContext(func(ctx context.Context, cmd *cobra.Command, args []string) error {
+			select {
+			case source <- syscall.SIGTERM:
+				t.Error("signal handler registered before the context was waited on")
+			default:
+			}
+
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}),
+		ConfigureSignals(WithSignalSource(source)),
+	)
+	root.SetArgs([]string{})
+
+	ctx, stop := runContext(root)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- root.ExecuteContext(ctx) }()
+
+	<-started
+	source <- syscall.SIGTERM
+
+	select {
+	case err := <-errs:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(time.Second):
+		t.Fatal("command not cancelled by signal")
+	}
+}
+
+func TestRunContext_Application(t *testing.T) {
+	source := make(chan os.Signal)
+	clock := NewFakeClock()
+
+	apps := make(chan *Application, 1)
+	root := Root("acme", "CLI sample application",
+		ExecuteContext(func(ctx context.Context, cmd *cobra.Command, args []string) error {
+			app := NewApplication(ctx)
+			apps <- app
+
+			return app.WaitForTermination(zap.NewNop(), 5*time.Second, time.Second)
+		}),
+		ConfigureSignals(WithSignalSource(source), WithClock(clock)),
+	)
+	root.SetArgs([]string{})
+
+	ctx, stop := runContext(root)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- root.ExecuteContext(ctx) }()
+
+	app := <-apps
+	select {
+	case source <- syscall.SIGTERM:
+	case <-time.After(time.Second):
+		t.Fatal("signal handler not registered by WaitForTermination")
+	}
+
+	require.Eventually(t, func() bool { return clock.PendingTimers() == 1 }, time.Second, time.Millisecond)
+	assert.False(t, app.IsReady(), "unready as soon as signaled")
+	assert.NoError(t, app.Context().Err(), "cancelled only once the unready period delay expired")
+	assert.NoError(t, ctx.Err())
+
+	clock.Advance(5 * time.Second)
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("application not terminated by signal")
+	}
+
+	assert.Equal(t, context.Canceled, app.Context().Err())
+	assert.Equal(t, context.Canceled, ctx.Err())
+}
+
+func TestRunContext_ApplicationDerivedContext(t *testing.T) {
+	source := make(chan os.Signal)
+	clock := NewFakeClock()
+
+	type ctxKey struct{}
+
+	apps := make(chan *Application, 1)
+	root := Root("acme", "CLI sample application",
+		ExecuteContext(func(ctx context.Context, cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(context.WithValue(ctx, ctxKey{}, "value"))
+			defer cancel()
+
+			app := NewApplication(ctx)
+			apps <- app
+
+			return app.WaitForTermination(zap.NewNop(), 0, time.Second)
+		}),
+		ConfigureSignals(WithSignalSource(source), WithClock(clock), WithUnreadyPeriodDelay(5*time.Second)),
+	)
+	root.SetArgs([]string{})
+
+	ctx, stop := runContext(root)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() { errs <- root.ExecuteContext(ctx) }()
+
+	app := <-apps
+	assert.Equal(t, "value", app.Context().Value(ctxKey{}))
+
+	select {
+	case source <- syscall.SIGTERM:
+	case <-time.After(time.Second):
+		t.Fatal("signal handler of the command's context not registered")
+	}
+
+	require.Eventually(t, func() bool { return clock.PendingTimers() == 1 }, time.Second, time.Millisecond)
+	assert.False(t, app.IsReady(), "the application shares the signal handler of the command's context")
+	assert.NoError(t, app.Context().Err())
+
+	clock.Advance(5 * time.Second)
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("application not terminated by signal")
+	}
+
+	assert.Equal(t, context.Canceled, app.Context().Err())
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package cli
+
+import (
+	"os"
+	"runtime/pprof"
+	"syscall"
+)
+
+// WithGoroutineDump makes the handler dump the stack of all goroutines to standard error when
+// SIGUSR1 is received, useful to troubleshoot a stuck process. It's a no-op on Windows.
+func WithGoroutineDump() SignalOption {
+	return WithSignalHandler(syscall.SIGUSR1, func(_ os.Signal) {
+		pprof.Lookup("goroutine").WriteTo(os.Stderr, 2)
+	})
+}
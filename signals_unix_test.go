@@ -0,0 +1,43 @@
+//go:build !windows
+
+package cli
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSignalHandler_CustomSignals(t *testing.T) {
+	reloaded := make(chan struct{}, 1)
+	custom := make(chan os.Signal, 1)
+
+	handler := NewSignalHandler(zap.NewNop(),
+		WithTerminationSignals(syscall.SIGTERM),
+		WithReloadHandler(func() { reloaded <- struct{}{} }),
+		WithSignalHandler(syscall.SIGUSR2, func(s os.Signal) { custom <- s }),
+	)
+	defer handler.Stop()
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("reload handler not invoked")
+	}
+
+	require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR2))
+	select {
+	case s := <-custom:
+		assert.Equal(t, syscall.SIGUSR2, s)
+	case <-time.After(time.Second):
+		t.Fatal("custom handler not invoked")
+	}
+
+	assert.False(t, handler.HasBeenSignaled.Load())
+}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package cli
+
+// WithGoroutineDump makes the handler dump the stack of all goroutines to standard error when
+// SIGUSR1 is received, useful to troubleshoot a stuck process. It's a no-op on Windows.
+func WithGoroutineDump() SignalOption {
+	return signalOptionFunc(func(opts *signalOptions) {})
+}
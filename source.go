@@ -0,0 +1,142 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ValueSource is the configuration layer a flag's value was resolved from.
+type ValueSource string
+
+const (
+	ValueSourceFlag    ValueSource = "flag"
+	ValueSourceEnv     ValueSource = "env"
+	ValueSourceConfig  ValueSource = "config"
+	ValueSourceDefault ValueSource = "default"
+	// ValueSourceFile is used for secrets read from their `--<name>-file` companion flag, see [Secret]
+	ValueSourceFile ValueSource = "file"
+)
+
+// FlagValueSource returns the configuration layer the value of `flag` is resolved from along
+// with the raw value found in that layer, following the priority documented on [ConfigureViper].
+//
+// The raw value is the textual value for [ValueSourceFlag], [ValueSourceEnv] and [ValueSourceDefault],
+// the value decoded from the file for [ValueSourceConfig] and the secret's file path for [ValueSourceFile]. For flags that were not rebound by
+// [ConfigureViper], only [ValueSourceFlag] and [ValueSourceDefault] can be returned.
+func FlagValueSource(cmd *cobra.Command, flag *pflag.Flag) (source ValueSource, raw any) {
+	if flag.Changed {
+		return ValueSourceFlag, flag.Value.String()
+	}
+
+	if resolved := flag.Annotations[resolvedSourceAnnotation]; len(resolved) == 2 {
+		return ValueSource(resolved[0]), resolved[1]
+	}
+
+	if key, found := reboundKey(flag); found {
+		if value, found := os.LookupEnv(envVarName(cmd, key)); found && value != "" {
+			return ValueSourceEnv, value
+		}
+
+		if v := ViperFor(cmd); v.InConfig(key) {
+			return ValueSourceConfig, v.Get(key)
+		}
+	}
+
+	return ValueSourceDefault, flag.DefValue
+}
+
+var resolvedSourceAnnotation = "github.com/streamingfast/cli#resolved-source"
+
+// setResolvedSource records the source of a value resolved by this library into the flag's
+// viper key, like secrets read from file or values provided via a deprecated alias.
+func setResolvedSource(flag *pflag.Flag, source ValueSource, raw any) {
+	if flag.Annotations == nil {
+		flag.Annotations = map[string][]string{}
+	}
+
+	flag.Annotations[resolvedSourceAnnotation] = []string{string(source), fmt.Sprint(raw)}
+}
+
+type configEntry struct {
+	Key    string      `json:"key"`
+	Value  any         `json:"value"`
+	Source ValueSource `json:"source"`
+	EnvVar string      `json:"env"`
+}
+
+// resolvedConfig returns the resolved value of every rebound key of the `cmd`'s tree, sorted
+// by key.
+func resolvedConfig(cmd *cobra.Command) (entries []configEntry) {
+	v := ViperFor(cmd)
+
+	visitReboundFlags(cmd.Root(), func(_ *cobra.Command, flag *pflag.Flag, key string) {
+		if isAliasFlag(flag) {
+			return
+		}
+
+		source, _ := FlagValueSource(cmd, flag)
+
+		entries = append(entries, configEntry{
+			Key:    key,
+			Value:  redactedValue(flag, v.Get(key)),
+			Source: source,
+			EnvVar: envVarName(cmd, key),
+		})
+	})
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	return entries
+}
+
+// writeResolvedConfig writes [resolvedConfig] to `out` as a table or as JSON depending
+// on `format`.
+func writeResolvedConfig(cmd *cobra.Command, out io.Writer, format string) error {
+	entries := resolvedConfig(cmd)
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(entries)
+
+	case "table", "":
+		writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE\tENV")
+		for _, entry := range entries {
+			fmt.Fprintf(writer, "%s\t%v\t%s\t%s\n", entry.Key, entry.Value, entry.Source, entry.EnvVar)
+		}
+
+		return writer.Flush()
+
+	default:
+		return fmt.Errorf("unsupported format %q, must be one of table, json", format)
+	}
+}
+
+func configurePrintConfigFlag(root *cobra.Command) {
+	root.PersistentFlags().String("print-config", "", "Print resolved configuration value and source of each flag ('table' or 'json') then exit")
+	root.PersistentFlags().Lookup("print-config").NoOptDefVal = "table"
+	root.PersistentFlags().MarkHidden("print-config")
+
+	addPreRunHook(root, preRunPhaseReport, func(cmd *cobra.Command) error {
+		flag := cmd.Flags().Lookup("print-config")
+		if flag == nil || !flag.Changed {
+			return nil
+		}
+
+		if err := writeResolvedConfig(cmd, cmd.OutOrStdout(), flag.Value.String()); err != nil {
+			return fmt.Errorf("print config: %w", err)
+		}
+
+		skipRun(cmd)
+		return nil
+	})
+}
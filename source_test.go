@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagValueSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme.yaml")
+	WriteFile(path, "%s", Dedent(`
+		read:
+		  from-config: "config"
+	`))
+
+	t.Setenv("ACME_READ_FROM_ENV", "env")
+
+	sources := map[string]ValueSource{}
+	raws := map[string]any{}
+	var dump bytes.Buffer
+
+	root := Root("acme", "CLI sample application",
+		Command(func(cmd *cobra.Command, args []string) error {
+			for _, name := range []string{"from-flag", "from-env", "from-config", "from-default"} {
+				sources[name], raws[name] = FlagValueSource(cmd, cmd.Flags().Lookup(name))
+			}
+
+			return writeResolvedConfig(cmd, &dump, "json")
+		}, "read", "Read command",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("from-flag", "", "")
+				flags.String("from-env", "", "")
+				flags.String("from-config", "", "")
+				flags.String("from-default", "default", "")
+			}),
+		),
+		ConfigureViperInstance(viper.New(), "ACME", WithPrintConfigFlag()),
+		ConfigureConfigFile("acme"),
+	)
+
+	root.SetArgs([]string{"read", "--config", path, "--from-flag", "flag"})
+	require.NoError(t, root.Execute())
+
+	assert.Equal(t, map[string]ValueSource{
+		"from-flag":    ValueSourceFlag,
+		"from-env":     ValueSourceEnv,
+		"from-config":  ValueSourceConfig,
+		"from-default": ValueSourceDefault,
+	}, sources)
+	assert.Equal(t, map[string]any{
+		"from-flag":    "flag",
+		"from-env":     "env",
+		"from-config":  "config",
+		"from-default": "default",
+	}, raws)
+
+	assert.JSONEq(t, `[
+		{"key": "global.config", "value": "`+path+`", "source": "flag", "env": "ACME_GLOBAL_CONFIG"},
+		{"key": "read.from-config", "value": "config", "source": "config", "env": "ACME_READ_FROM_CONFIG"},
+		{"key": "read.from-default", "value": "default", "source": "default", "env": "ACME_READ_FROM_DEFAULT"},
+		{"key": "read.from-env", "value": "env", "source": "env", "env": "ACME_READ_FROM_ENV"},
+		{"key": "read.from-flag", "value": "flag", "source": "flag", "env": "ACME_READ_FROM_FLAG"}
+	]`, dump.String())
+}
+
+func TestPrintConfigFlag(t *testing.T) {
+	ran := 0
+	root := Root("acme", "CLI sample application",
+		Command(func(cmd *cobra.Command, args []string) error {
+			ran++
+			return nil
+		}, "read", "Read command",
+			Flags(func(flags *pflag.FlagSet) {
+				flags.String("endpoint", "localhost:9000", "")
+			}),
+		),
+		ConfigureViperInstance(viper.New(), "ACME", WithPrintConfigFlag()),
+	)
+
+	var out bytes.Buffer
+	root.SetOut(&out)
+
+	root.SetArgs([]string{"read", "--print-config"})
+	require.NoError(t, root.Execute())
+	assert.Equal(t, 0, ran, "command not run when printing the config")
+	assert.Contains(t, out.String(), "read.endpoint")
+	assert.Contains(t, out.String(), "localhost:9000")
+
+	// Cobra keeps parsed flags between executions, the restored handler is called directly
+	read, _, err := root.Find([]string{"read"})
+	require.NoError(t, err)
+	require.NoError(t, read.RunE(read, nil))
+	assert.Equal(t, 1, ran, "command's handler restored for the next execution")
+}
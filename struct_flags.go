@@ -0,0 +1,327 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// FlagsFromStruct is a [Flags] option that defines a flag for each field of the struct pointed
+// to by `config` that has a `flag` tag. The struct can then be filled with the resolved values
+// (flags, environment, config file, defaults) by using `sflags.Bind(cmd, config)` within your
+// command's execution.
+//
+// The following tags are recognized:
+//
+//	flag:"<name>[,<modifier>]" Flag's name, the modifier picks an alternative flag type for the Go type
+//	default:"<value>"          Flag's default value, parsed like it would be on the command line, when absent, the field's current value is used
+//	usage:"<usage>"            Flag's usage, multi-line usage are processed through [FlagDescription]
+//
+// Every flag type supported by `sflags` can be defined, the flag's type is inferred from the
+// field's Go type. The modifiers `count` (`int` field), `array` (`[]string` field) and `base64`
+// (`[]byte` field) respectively define a `Count`, `StringArray` and `BytesBase64` flag instead
+// of the default `Int`, `StringSlice` and `BytesHex` ones.
+//
+//	type readConfig struct {
+//		SkipErrors bool          `flag:"skip-errors" usage:"Skip read errors"`
+//		Timeout    time.Duration `flag:"timeout" default:"30s" usage:"Read timeout"`
+//		Verbosity  int           `flag:"verbose,count" usage:"Verbosity level, repeat to increase"`
+//	}
+//
+// Named types are supported through their underlying type, so a `type Level int` field defines an
+// `Int` flag. Fields tagged `flag:"-"` are skipped.
+//
+// Anonymous struct fields without a `flag` tag are traversed, which makes it possible to share
+// common definitions. Invalid definitions, like a `flag` tag on an unexported field, are programming
+// errors and panic.
+func FlagsFromStruct(config any) CommandOption {
+	return Flags(func(flags *pflag.FlagSet) {
+		defineStructFlags(flags, config)
+	})
+}
+
+// PersistentFlagsFromStruct is exactly like [FlagsFromStruct] but defines persistent flags.
+func PersistentFlagsFromStruct(config any) CommandOption {
+	return PersistentFlags(func(flags *pflag.FlagSet) {
+		defineStructFlags(flags, config)
+	})
+}
+
+func defineStructFlags(flags *pflag.FlagSet, config any) {
+	value := reflect.ValueOf(config)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Struct {
+		panic(fmt.Errorf("flags from struct requires a pointer to a struct, got %T", config))
+	}
+
+	defineStructValueFlags(flags, value.Elem())
+}
+
+func defineStructValueFlags(flags *pflag.FlagSet, value reflect.Value) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		tag, hasTag := field.Tag.Lookup("flag")
+		if !hasTag {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				defineStructValueFlags(flags, value.Field(i))
+			}
+
+			continue
+		}
+
+		if tag == "-" {
+			continue
+		}
+
+		name, modifier, _ := strings.Cut(tag, ",")
+		if name == "" {
+			panic(fmt.Errorf("field %s has an empty flag name", field.Name))
+		}
+
+		if !field.IsExported() {
+			panic(fmt.Errorf("field %s (flag %q) must be exported to be defined from struct", field.Name, name))
+		}
+
+		flagType, goType, found := structFlagType(field.Type, modifier)
+		if !found {
+			panic(fmt.Errorf("field %s of type %s with modifier %q (flag %q) is not a supported flag type", field.Name, field.Type, modifier, name))
+		}
+
+		usage := field.Tag.Get("usage")
+		if strings.Contains(usage, "\n") {
+			usage = FlagDescription(usage)
+		}
+
+		// We resolve the typed default value by parsing it through a scratch flag, defining the
+		// real flag with the typed default value afterward ensures its 'Value' starts pristine.
+		defaultValue := reflect.New(goType)
+		defaultValue.Elem().Set(value.Field(i).Convert(goType))
+
+		if rawDefault, found := field.Tag.Lookup("default"); found {
+			scratch := pflag.NewFlagSet("scratch", pflag.ContinueOnError)
+			defineTypedFlag(scratch, flagType, name, defaultValue.Interface(), usage)
+
+			if err := scratch.Set(name, rawDefault); err != nil {
+				panic(fmt.Errorf("field %s default value %q (flag %q) is invalid: %w", field.Name, rawDefault, name, err))
+			}
+		}
+
+		defineTypedFlag(flags, flagType, name, defaultValue.Interface(), usage)
+	}
+}
+
+var structFlagTypes = map[reflect.Type]string{
+	reflect.TypeOf([]bool{}):            "BoolSlice",
+	reflect.TypeOf(uint8(0)):            "Uint8",
+	reflect.TypeOf([]string{}):          "StringSlice",
+	reflect.TypeOf([]net.IP{}):          "IPSlice",
+	reflect.TypeOf(map[string]string{}): "StringToString",
+	reflect.TypeOf(float64(0)):          "Float64",
+	reflect.TypeOf(uint32(0)):           "Uint32",
+	reflect.TypeOf([]time.Duration{}):   "DurationSlice",
+	reflect.TypeOf(uint16(0)):           "Uint16",
+	reflect.TypeOf([]float32{}):         "Float32Slice",
+	reflect.TypeOf(time.Duration(0)):    "Duration",
+	reflect.TypeOf(int64(0)):            "Int64",
+	reflect.TypeOf([]uint{}):            "UintSlice",
+	reflect.TypeOf(false):               "Bool",
+	reflect.TypeOf([]int32{}):           "Int32Slice",
+	reflect.TypeOf(int32(0)):            "Int32",
+	reflect.TypeOf(map[string]int{}):    "StringToInt",
+	reflect.TypeOf(int16(0)):            "Int16",
+	reflect.TypeOf(net.IP{}):            "IP",
+	reflect.TypeOf(net.IPNet{}):         "IPNet",
+	reflect.TypeOf(uint64(0)):           "Uint64",
+	reflect.TypeOf(map[string]int64{}):  "StringToInt64",
+	reflect.TypeOf(float32(0)):          "Float32",
+	reflect.TypeOf(net.IPMask{}):        "IPv4Mask",
+	reflect.TypeOf(int(0)):              "Int",
+	reflect.TypeOf(uint(0)):             "Uint",
+	reflect.TypeOf([]float64{}):         "Float64Slice",
+	reflect.TypeOf(int8(0)):             "Int8",
+	reflect.TypeOf([]byte{}):            "BytesHex",
+	reflect.TypeOf([]int{}):             "IntSlice",
+	reflect.TypeOf(""):                  "String",
+	reflect.TypeOf([]int64{}):           "Int64Slice",
+}
+
+var structFlagModifiers = map[string]map[string]string{
+	"count":  {"Int": "Count"},
+	"array":  {"StringSlice": "StringArray"},
+	"base64": {"BytesHex": "BytesBase64"},
+}
+
+// structFlagType returns the flag type of `fieldType` along the Go type storing the flag's value,
+// which is the underlying type of `fieldType` when it's a named type unknown to pflag.
+func structFlagType(fieldType reflect.Type, modifier string) (string, reflect.Type, bool) {
+	goType := fieldType
+	flagType, found := structFlagTypes[goType]
+	if !found {
+		if goType, found = underlyingType(fieldType); found {
+			flagType, found = structFlagTypes[goType]
+		}
+	}
+
+	if !found || modifier == "" {
+		return flagType, goType, found
+	}
+
+	flagType, found = structFlagModifiers[modifier][flagType]
+	return flagType, goType, found
+}
+
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:    reflect.TypeOf(false),
+	reflect.Int:     reflect.TypeOf(int(0)),
+	reflect.Int8:    reflect.TypeOf(int8(0)),
+	reflect.Int16:   reflect.TypeOf(int16(0)),
+	reflect.Int32:   reflect.TypeOf(int32(0)),
+	reflect.Int64:   reflect.TypeOf(int64(0)),
+	reflect.Uint:    reflect.TypeOf(uint(0)),
+	reflect.Uint8:   reflect.TypeOf(uint8(0)),
+	reflect.Uint16:  reflect.TypeOf(uint16(0)),
+	reflect.Uint32:  reflect.TypeOf(uint32(0)),
+	reflect.Uint64:  reflect.TypeOf(uint64(0)),
+	reflect.Float32: reflect.TypeOf(float32(0)),
+	reflect.Float64: reflect.TypeOf(float64(0)),
+	reflect.String:  reflect.TypeOf(""),
+}
+
+// underlyingType returns the unnamed type `t` is defined from, like `int` for `type Level int`.
+func underlyingType(t reflect.Type) (reflect.Type, bool) {
+	switch t.Kind() {
+	case reflect.Slice:
+		return reflect.SliceOf(t.Elem()), true
+	case reflect.Map:
+		return reflect.MapOf(t.Key(), t.Elem()), true
+	}
+
+	underlying, found := kindTypes[t.Kind()]
+	return underlying, found
+}
+
+// defineTypedFlag defines the flag `name` of type `flagType` storing its value in `p` and
+// using the current value pointed to by `p` as the flag's default value.
+func defineTypedFlag(flags *pflag.FlagSet, flagType string, name string, p any, usage string) {
+	switch flagType {
+	case "BoolSlice":
+		v := p.(*[]bool)
+		flags.BoolSliceVar(v, name, *v, usage)
+	case "Uint8":
+		v := p.(*uint8)
+		flags.Uint8Var(v, name, *v, usage)
+	case "StringSlice":
+		v := p.(*[]string)
+		flags.StringSliceVar(v, name, *v, usage)
+	case "IPSlice":
+		v := p.(*[]net.IP)
+		flags.IPSliceVar(v, name, *v, usage)
+	case "StringToString":
+		v := p.(*map[string]string)
+		flags.StringToStringVar(v, name, *v, usage)
+	case "Float64":
+		v := p.(*float64)
+		flags.Float64Var(v, name, *v, usage)
+	case "Uint32":
+		v := p.(*uint32)
+		flags.Uint32Var(v, name, *v, usage)
+	case "DurationSlice":
+		v := p.(*[]time.Duration)
+		flags.DurationSliceVar(v, name, *v, usage)
+	case "Uint16":
+		v := p.(*uint16)
+		flags.Uint16Var(v, name, *v, usage)
+	case "Float32Slice":
+		v := p.(*[]float32)
+		flags.Float32SliceVar(v, name, *v, usage)
+	case "Duration":
+		v := p.(*time.Duration)
+		flags.DurationVar(v, name, *v, usage)
+	case "Int64":
+		v := p.(*int64)
+		flags.Int64Var(v, name, *v, usage)
+	case "UintSlice":
+		v := p.(*[]uint)
+		flags.UintSliceVar(v, name, *v, usage)
+	case "Bool":
+		v := p.(*bool)
+		flags.BoolVar(v, name, *v, usage)
+	case "Int32Slice":
+		v := p.(*[]int32)
+		flags.Int32SliceVar(v, name, *v, usage)
+	case "Int32":
+		v := p.(*int32)
+		flags.Int32Var(v, name, *v, usage)
+	case "StringToInt":
+		v := p.(*map[string]int)
+		flags.StringToIntVar(v, name, *v, usage)
+	case "Int16":
+		v := p.(*int16)
+		flags.Int16Var(v, name, *v, usage)
+	case "IP":
+		v := p.(*net.IP)
+		flags.IPVar(v, name, *v, usage)
+	case "IPNet":
+		v := p.(*net.IPNet)
+		flags.IPNetVar(v, name, *v, usage)
+	case "Uint64":
+		v := p.(*uint64)
+		flags.Uint64Var(v, name, *v, usage)
+	case "StringToInt64":
+		v := p.(*map[string]int64)
+		flags.StringToInt64Var(v, name, *v, usage)
+	case "Float32":
+		v := p.(*float32)
+		flags.Float32Var(v, name, *v, usage)
+	case "IPv4Mask":
+		v := p.(*net.IPMask)
+		flags.IPMaskVar(v, name, *v, usage)
+	case "Count":
+		// pflag offers no way to provide the default value of count flags and resets the value
+		// to 0, so it's restored afterward, repeating the flag then increments from it
+		v := p.(*int)
+		defaultValue := *v
+		flags.CountVar(v, name, usage)
+		if defaultValue != 0 {
+			*v = defaultValue
+			flags.Lookup(name).DefValue = strconv.Itoa(defaultValue)
+		}
+	case "Int":
+		v := p.(*int)
+		flags.IntVar(v, name, *v, usage)
+	case "Uint":
+		v := p.(*uint)
+		flags.UintVar(v, name, *v, usage)
+	case "Float64Slice":
+		v := p.(*[]float64)
+		flags.Float64SliceVar(v, name, *v, usage)
+	case "Int8":
+		v := p.(*int8)
+		flags.Int8Var(v, name, *v, usage)
+	case "BytesHex":
+		v := p.(*[]byte)
+		flags.BytesHexVar(v, name, *v, usage)
+	case "BytesBase64":
+		v := p.(*[]byte)
+		flags.BytesBase64Var(v, name, *v, usage)
+	case "IntSlice":
+		v := p.(*[]int)
+		flags.IntSliceVar(v, name, *v, usage)
+	case "String":
+		v := p.(*string)
+		flags.StringVar(v, name, *v, usage)
+	case "StringArray":
+		v := p.(*[]string)
+		flags.StringArrayVar(v, name, *v, usage)
+	case "Int64Slice":
+		v := p.(*[]int64)
+		flags.Int64SliceVar(v, name, *v, usage)
+	default:
+		panic(fmt.Errorf("unhandled flag type %q", flagType))
+	}
+}
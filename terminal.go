@@ -3,14 +3,12 @@ package cli
 import (
 	"errors"
 	"fmt"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/lithammer/dedent"
 	"github.com/manifoldco/promptui"
-	"golang.org/x/term"
 )
 
 // Prompt will ask the following "label" question to the user and will transform the received `string`
@@ -53,8 +51,17 @@ func PromptConfirm(label string, opts ...PromptOption) (answer bool, wasAnswered
 }
 
 // MaybePromptConfirm is just like [PromptConfirm] but returns an error instead of panicking.
+//
+// When prompting is not possible (see [ConfigureNonInteractive]), the confirmation is reported
+// as not answered unless `--yes` was provided, it was answered in advance or it has a default
+// value (see [WithPromptDefaultValue]), the default value being the answer then like for [PromptRaw].
 func MaybePromptConfirm(label string, opts ...PromptOption) (answer bool, wasAnswered bool, err error) {
-	if !term.IsTerminal(int(os.Stdout.Fd())) {
+	options := promptOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	if _, found := scriptedAnswer(options.id); !found && !IsInteractive() && !currentPromptMode().assumeYes && options.defaultValue == "" {
 		wasAnswered = false
 		return
 	}
@@ -80,6 +87,27 @@ func MaybePromptSelect[T any](label string, items []string, transformer PromptTr
 		opt.Apply(&options)
 	}
 
+	var empty T
+	if answer, found := scriptedAnswer(options.id); found {
+		if indexOf(items, answer) == -1 {
+			return empty, fmt.Errorf("invalid answer %q for prompt %q, must be one of %s", answer, options.id, strings.Join(items, ", "))
+		}
+
+		return transformer(answer)
+	}
+
+	if !IsInteractive() {
+		if options.defaultValue == "" {
+			return empty, nonInteractiveError(label, options.id)
+		}
+
+		if indexOf(items, options.defaultValue) == -1 {
+			return empty, fmt.Errorf("invalid default value %q for prompt %q, must be one of %s", options.defaultValue, label, strings.Join(items, ", "))
+		}
+
+		return transformer(options.defaultValue)
+	}
+
 	choice := promptui.Select{
 		Label:     label,
 		Items:     items,
@@ -87,14 +115,17 @@ func MaybePromptSelect[T any](label string, items []string, transformer PromptTr
 		Templates: options.selectTemplates,
 	}
 
+	if position := indexOf(items, options.defaultValue); position != -1 {
+		choice.CursorPos = position
+	}
+
 	_, selection, err := choice.Run()
 	if err != nil {
 		if errors.Is(err, promptui.ErrInterrupt) {
 			// We received Ctrl-C, users wants to abort, nothing else to do, quit immediately
-			Exit(1)
+			Exit(ExitCodeInterrupted)
 		}
 
-		var empty T
 		return empty, fmt.Errorf("running protocol prompt: %w", err)
 	}
 
@@ -102,7 +133,11 @@ func MaybePromptSelect[T any](label string, items []string, transformer PromptTr
 }
 
 func AskConfirmation(label string, args ...interface{}) (answeredYes bool, wasAnswered bool) {
-	if !term.IsTerminal(int(os.Stdout.Fd())) {
+	if !IsInteractive() {
+		if currentPromptMode().assumeYes {
+			return true, true
+		}
+
 		wasAnswered = false
 		return
 	}
@@ -169,6 +204,18 @@ func WithPromptValidate(label string, fn promptui.ValidateFunc) PromptOption {
 	})
 }
 
+type promptIDOption string
+
+func (o promptIDOption) Apply(opts *promptOptions) {
+	opts.id = string(o)
+}
+
+// WithPromptID identifies the prompt so that it can be answered in advance, see
+// [ConfigureNonInteractive].
+func WithPromptID(id string) PromptOption {
+	return promptIDOption(id)
+}
+
 type promptTemplatesOption promptui.PromptTemplates
 
 func (o *promptTemplatesOption) Apply(opts *promptOptions) {
@@ -180,18 +227,40 @@ func WithPromptTemplates(templates *promptui.PromptTemplates) PromptOption {
 }
 
 type promptOptions struct {
+	id              string
 	validate        promptui.ValidateFunc
 	isConfirm       bool
 	promptTemplates *promptui.PromptTemplates
 	defaultValue    string
 }
 
+// PromptRaw asks `label` to the user and returns the answer as-is, see [ConfigureNonInteractive]
+// for how it's answered when prompting is not possible.
 func PromptRaw(label string, opts ...PromptOption) (answer string, err error) {
 	options := promptOptions{}
 	for _, opt := range opts {
 		opt.Apply(&options)
 	}
 
+	validate := options.validate
+	if validate == nil && options.isConfirm {
+		validate = PrompValidateYesNo
+	}
+
+	if answer, found := scriptedAnswer(options.id); found {
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				return "", fmt.Errorf("invalid answer %q for prompt %q: %w", answer, options.id, err)
+			}
+		}
+
+		return answer, nil
+	}
+
+	if !IsInteractive() {
+		return nonInteractiveAnswer(label, options)
+	}
+
 	templates := options.promptTemplates
 
 	if templates == nil {
@@ -209,14 +278,7 @@ func PromptRaw(label string, opts ...PromptOption) (answer string, err error) {
 	prompt := promptui.Prompt{
 		Label:     label,
 		Templates: templates,
-	}
-
-	if options.validate != nil {
-		prompt.Validate = options.validate
-	}
-
-	if prompt.Validate == nil && options.isConfirm {
-		prompt.Validate = PrompValidateYesNo
+		Validate:  validate,
 	}
 
 	if options.defaultValue != "" {
@@ -227,7 +289,7 @@ func PromptRaw(label string, opts ...PromptOption) (answer string, err error) {
 	choice, err := prompt.Run()
 	if err != nil {
 		if errors.Is(err, promptui.ErrInterrupt) {
-			Exit(1)
+			Exit(ExitCodeInterrupted)
 		}
 
 		if prompt.IsConfirm && errors.Is(err, promptui.ErrAbort) {
@@ -278,6 +340,8 @@ var confirmPromptRegex = regexp.MustCompile("(y|Y|n|N|No|Yes|YES|NO)")
 type PromptTransformer[T any] func(string) (T, error)
 
 type promptSelectOptions struct {
+	id              string
+	defaultValue    string
 	selectTemplates *promptui.SelectTemplates
 }
 
@@ -291,6 +355,39 @@ func (o *promptSelectTemplatesOption) Apply(opts *promptSelectOptions) {
 	opts.selectTemplates = (*promptui.SelectTemplates)(o)
 }
 
+type promptSelectIDOption string
+
+func (o promptSelectIDOption) Apply(opts *promptSelectOptions) {
+	opts.id = string(o)
+}
+
+// WithPromptSelectID is like [WithPromptID] for select prompts, the answer must be one of the items.
+func WithPromptSelectID(id string) PromptSelectOption {
+	return promptSelectIDOption(id)
+}
+
+type promptSelectDefaultValueOption string
+
+func (o promptSelectDefaultValueOption) Apply(opts *promptSelectOptions) {
+	opts.defaultValue = string(o)
+}
+
+// WithPromptSelectDefaultValue sets the item selected initially, it's also the answer when
+// prompting is not possible, see [ConfigureNonInteractive].
+func WithPromptSelectDefaultValue(item string) PromptSelectOption {
+	return promptSelectDefaultValueOption(item)
+}
+
+func indexOf(items []string, item string) int {
+	for i, candidate := range items {
+		if candidate == item {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func WithPromptSelectTemplates(templates *promptui.SelectTemplates) PromptSelectOption {
 	return (*promptSelectTemplatesOption)(templates)
 }
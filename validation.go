@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/constraints"
+)
+
+// Required ensures that each flag in `names` is provided by one of the configuration layers,
+// so a value coming from an environment variable or the config file (when [ConfigureViper]
+// is used) satisfies the requirement.
+func Required(names ...string) CommandOption {
+	return flagValidation(names, func(cmd *cobra.Command) error {
+		for _, name := range names {
+			flag := validatedFlag(cmd, name)
+			if !flagProvided(cmd, flag) {
+				return usageErrorf("required flag --%s not provided%s", name, flagSourcesHint(cmd, flag))
+			}
+		}
+
+		return nil
+	})
+}
+
+// MutuallyExclusive ensures that at most one flag in `names` is provided.
+func MutuallyExclusive(names ...string) CommandOption {
+	return flagValidation(names, func(cmd *cobra.Command) error {
+		var provided *pflag.Flag
+		for _, name := range names {
+			flag := validatedFlag(cmd, name)
+			if !flagProvided(cmd, flag) {
+				continue
+			}
+
+			if provided != nil {
+				return usageErrorf("flags --%s (from %s) and --%s (from %s) cannot be provided at the same time",
+					provided.Name, flagSourceDescription(cmd, provided), flag.Name, flagSourceDescription(cmd, flag))
+			}
+
+			provided = flag
+		}
+
+		return nil
+	})
+}
+
+// RequiredTogether ensures that either all flags in `names` are provided or none of them.
+func RequiredTogether(names ...string) CommandOption {
+	return flagValidation(names, func(cmd *cobra.Command) error {
+		var provided, missing *pflag.Flag
+		for _, name := range names {
+			flag := validatedFlag(cmd, name)
+			if flagProvided(cmd, flag) {
+				if provided == nil {
+					provided = flag
+				}
+			} else if missing == nil {
+				missing = flag
+			}
+		}
+
+		if provided != nil && missing != nil {
+			return usageErrorf("flags --%s must be provided together, --%s is missing while --%s is provided (from %s)",
+				strings.Join(names, ", --"), missing.Name, provided.Name, flagSourceDescription(cmd, provided))
+		}
+
+		return nil
+	})
+}
+
+// OneOf ensures that the value of flag `name`, when provided, is one of `values`.
+func OneOf(name string, values ...string) CommandOption {
+	return flagValidation([]string{name}, func(cmd *cobra.Command) error {
+		flag := validatedFlag(cmd, name)
+		if !flagProvided(cmd, flag) {
+			return nil
+		}
+
+		value := cast.ToString(resolvedFlagValue(cmd, flag))
+		for _, candidate := range values {
+			if value == candidate {
+				return nil
+			}
+		}
+
+		return usageErrorf("invalid value %q for --%s (from %s), must be one of %q", value, name, flagSourceDescription(cmd, flag), values)
+	})
+}
+
+// Range ensures that the value of flag `name`, when provided, is within `min` and `max`
+// inclusively. Any numeric flag type as well as `time.Duration` flags are supported.
+func Range[T constraints.Integer | constraints.Float](name string, min, max T) CommandOption {
+	return flagValidation([]string{name}, func(cmd *cobra.Command) error {
+		flag := validatedFlag(cmd, name)
+		if !flagProvided(cmd, flag) {
+			return nil
+		}
+
+		value, err := rangeValue[T](resolvedFlagValue(cmd, flag))
+		if err != nil {
+			return usageErrorf("invalid value for --%s (from %s): %w", name, flagSourceDescription(cmd, flag), err)
+		}
+
+		if value < min || value > max {
+			return usageErrorf("invalid value %v for --%s (from %s), must be between %v and %v", value, name, flagSourceDescription(cmd, flag), min, max)
+		}
+
+		return nil
+	})
+}
+
+var annotationValidatedFlags = "validated-flags"
+
+// flagValidation installs `validate` on the command and its descendants. It only runs for the
+// commands where all the flags `names` are visible, a local flag of the command not being
+// visible to its sub-commands for example.
+//
+// The flags must be defined on the command once the whole tree is built, see [checkValidatedFlags].
+func flagValidation(names []string, validate preRunHook) CommandOption {
+	return CommandOptionFunc(func(owner *cobra.Command) {
+		var validated []string
+		if existing, found := getCommandAnnotation(owner, annotationValidatedFlags); found {
+			validated = existing.([]string)
+		}
+		setCommandAnnotation(owner, annotationValidatedFlags, append(validated, names...))
+
+		addPreRunHook(owner, preRunPhaseValidate, func(cmd *cobra.Command) error {
+			for _, name := range names {
+				if cmd.Flags().Lookup(name) == nil {
+					return nil
+				}
+			}
+
+			return validate(cmd)
+		})
+	})
+}
+
+// checkValidatedFlags panics if a flag validated on a command of the tree is not defined on it,
+// usually a typo or a renamed flag. It runs on the root once the tree is built, sub-commands
+// being able to validate the persistent flags of their ancestors.
+func checkValidatedFlags(root *cobra.Command) {
+	visitAllCommands(root, func(cmd *cobra.Command) {
+		names, found := getCommandAnnotation(cmd, annotationValidatedFlags)
+		if !found {
+			return
+		}
+
+		for _, name := range names.([]string) {
+			if cmd.Flags().Lookup(name) == nil && cmd.PersistentFlags().Lookup(name) == nil && cmd.InheritedFlags().Lookup(name) == nil {
+				panic(fmt.Errorf("flag %q validated but not defined on command %q", name, cmd.CommandPath()))
+			}
+		}
+	})
+}
+
+// validatedFlag looks up the flag at execution time, so inherited persistent flags are
+// found too. The flag is known to be visible, see [flagValidation].
+func validatedFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	return cmd.Flags().Lookup(name)
+}
+
+func flagProvided(cmd *cobra.Command, flag *pflag.Flag) bool {
+	source, _ := FlagValueSource(cmd, flag)
+	return source != ValueSourceDefault
+}
+
+// resolvedFlagValue returns the viper value for rebound flags and the textual value otherwise.
+func resolvedFlagValue(cmd *cobra.Command, flag *pflag.Flag) any {
+	if key, found := reboundKey(flag); found {
+		return ViperFor(cmd).Get(key)
+	}
+
+	return flag.Value.String()
+}
+
+// flagSourceDescription describes where the value of `flag` comes from, like `env ACME_GLOBAL_PORT`.
+func flagSourceDescription(cmd *cobra.Command, flag *pflag.Flag) string {
+	source, raw := FlagValueSource(cmd, flag)
+	key, _ := reboundKey(flag)
+
+	switch source {
+	case ValueSourceEnv:
+		return fmt.Sprintf("env %s", envVarName(cmd, key))
+	case ValueSourceConfig:
+		if path, found := ConfigFileUsed(cmd); found {
+			return fmt.Sprintf("config key %s in %s", key, path)
+		}
+
+		return fmt.Sprintf("config key %s", key)
+	case ValueSourceFile:
+		return fmt.Sprintf("file %s", raw)
+	}
+
+	return string(source)
+}
+
+func flagSourcesHint(cmd *cobra.Command, flag *pflag.Flag) string {
+	key, found := reboundKey(flag)
+	if !found {
+		return ""
+	}
+
+	return fmt.Sprintf(", set it with --%s, env %s or config key %s", flag.Name, envVarName(cmd, key), key)
+}
+
+func rangeValue[T constraints.Integer | constraints.Float](raw any) (T, error) {
+	var zero T
+	if _, ok := any(zero).(time.Duration); ok {
+		value, err := cast.ToDurationE(raw)
+		return T(value), err
+	}
+
+	switch reflect.TypeOf(zero).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := cast.ToInt64E(raw)
+		return T(value), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := cast.ToUint64E(raw)
+		return T(value), err
+	default:
+		value, err := cast.ToFloat64E(raw)
+		return T(value), err
+	}
+}
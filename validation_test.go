@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagValidations(t *testing.T) {
+	tests := []struct {
+		name      string
+		option    CommandOption
+		args      []string
+		env       map[string]string
+		wantError string
+	}{
+		{"required missing", Required("output"), nil, nil, "required flag --output not provided, set it with --output, env ACME_GLOBAL_OUTPUT or config key global.output"},
+		{"required from flag", Required("output"), []string{"--output", "json"}, nil, ""},
+		{"required from env", Required("output"), nil, map[string]string{"ACME_GLOBAL_OUTPUT": "json"}, ""},
+		{"exclusive none", MutuallyExclusive("output", "quiet"), nil, nil, ""},
+		{"exclusive one", MutuallyExclusive("output", "quiet"), []string{"--quiet"}, nil, ""},
+		{"exclusive both", MutuallyExclusive("output", "quiet"), []string{"--quiet"}, map[string]string{"ACME_GLOBAL_OUTPUT": "json"}, "flags --output (from env ACME_GLOBAL_OUTPUT) and --quiet (from flag) cannot be provided at the same time"},
+		{"together none", RequiredTogether("output", "timeout"), nil, nil, ""},
+		{"together both", RequiredTogether("output", "timeout"), []string{"--output", "json", "--timeout", "1s"}, nil, ""},
+		{"together partial", RequiredTogether("output", "timeout"), []string{"--timeout", "1s"}, nil, "flags --output, --timeout must be provided together, --output is missing while --timeout is provided (from flag)"},
+		{"one of default", OneOf("output", "text", "json"), nil, nil, ""},
+		{"one of valid", OneOf("output", "text", "json"), []string{"--output", "json"}, nil, ""},
+		{"one of invalid", OneOf("output", "text", "json"), nil, map[string]string{"ACME_GLOBAL_OUTPUT": "xml"}, `invalid value "xml" for --output (from env ACME_GLOBAL_OUTPUT), must be one of ["text" "json"]`},
+		{"range valid", Range("port", 1, 65535), []string{"--port", "8080"}, nil, ""},
+		{"range invalid", Range("port", 1, 65535), nil, map[string]string{"ACME_GLOBAL_PORT": "70000"}, "invalid value 70000 for --port (from env ACME_GLOBAL_PORT), must be between 1 and 65535"},
+		{"range duration", Range("timeout", time.Second, time.Minute), []string{"--timeout", "2m"}, nil, "invalid value 2m0s for --timeout (from flag), must be between 1s and 1m0s"},
+		{"range float", Range("ratio", 0.0, 1.0), []string{"--ratio", "0.5"}, nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			root := Root("acme", "CLI sample application",
+				Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+				PersistentFlags(func(flags *pflag.FlagSet) {
+					flags.String("output", "text", "Output format")
+					flags.Bool("quiet", false, "Quiet mode")
+					flags.Duration("timeout", 0, "Timeout")
+					flags.Int("port", 9000, "Port")
+					flags.Float64("ratio", 0, "Ratio")
+				}),
+				tt.option,
+				ConfigureViperInstance(viper.New(), "ACME"),
+			)
+
+			root.SetArgs(tt.args)
+			err := root.Execute()
+			if tt.wantError == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var usageErr *UsageError
+			assert.True(t, errors.As(err, &usageErr))
+			assert.EqualError(t, err, tt.wantError)
+		})
+	}
+}
+
+func TestFlagValidations_LocalFlagWithSubCommand(t *testing.T) {
+	root := Root("acme", "CLI sample application",
+		Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+		Flags(func(flags *pflag.FlagSet) {
+			flags.String("output", "", "Output format")
+			flags.Int("port", 0, "Port")
+		}),
+		Required("output"),
+		OneOf("output", "text", "json"),
+		Range("port", 1, 65535),
+		Command(func(cmd *cobra.Command, args []string) error { return nil }, "sub", "Sub command"),
+		ConfigureViperInstance(viper.New(), "ACME"),
+	)
+
+	root.SetArgs([]string{"sub"})
+	require.NoError(t, root.Execute())
+
+	root.SetArgs(nil)
+	assert.EqualError(t, root.Execute(), "required flag --output not provided, set it with --output, env ACME_OUTPUT or config key output")
+}
+
+func TestFlagValidations_UndefinedFlag(t *testing.T) {
+	assert.PanicsWithError(t, `flag "missing" validated but not defined on command "acme"`, func() {
+		Root("acme", "CLI sample application",
+			Execute(func(cmd *cobra.Command, args []string) error { return nil }),
+			Required("missing"),
+		)
+	})
+
+	assert.PanicsWithError(t, `flag "outptu" validated but not defined on command "acme read"`, func() {
+		Root("acme", "CLI sample application",
+			Command(func(cmd *cobra.Command, args []string) error { return nil }, "read", "Read command",
+				OneOf("outptu", "json", "text"),
+			),
+			PersistentFlags(func(flags *pflag.FlagSet) { flags.String("output", "text", "Output format") }),
+		)
+	})
+
+	assert.NotPanics(t, func() {
+		Root("acme", "CLI sample application",
+			Command(func(cmd *cobra.Command, args []string) error { return nil }, "read", "Read command",
+				OneOf("output", "json", "text"),
+			),
+			PersistentFlags(func(flags *pflag.FlagSet) { flags.String("output", "text", "Output format") }),
+		)
+	}, "persistent flags of ancestors can be validated")
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"strings"
+	"sync"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -11,23 +12,143 @@ import (
 
 var ReboundFlagAnnotation = "github.com/streamingfast/cli#rebound-key"
 
+var annotationViper = "viper"
+var annotationEnvPrefix = "env-prefix"
+
+// For backward compatibility, we support access through "_" and through "." for now,
+// configuring the actual key delimiter use on the viper instance is not possible.
+var envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// ViperOption customizes the behavior of [ConfigureViper] and its variants.
+type ViperOption interface {
+	Apply(opts *viperOptions)
+}
+
+type viperOptions struct {
+	printConfigFlag bool
+}
+
+type viperPrintConfigFlagOption bool
+
+func (o viperPrintConfigFlagOption) Apply(opts *viperOptions) {
+	opts.printConfigFlag = bool(o)
+}
+
+// WithPrintConfigFlag adds an hidden `--print-config` persistent flag on the root command which,
+// when present, prints every rebound key along with its resolved value and source (see
+// [FlagValueSource]) and then returns successfully instead of running the command. The output is
+// a table by default, use `--print-config=json` to get JSON.
+func WithPrintConfigFlag() ViperOption {
+	return viperPrintConfigFlagOption(true)
+}
+
+// ConfigureViperInstance is exactly like [ConfigureViper] but binds the flags into the
+// received viper instance instead of the global viper singleton. Use [ViperFor] to retrieve
+// the instance from any command of the tree, `sflags` getters do it automatically.
+//
+// This is useful when multiple CLI trees live in the same process, like when embedding two
+// CLIs in one binary or when running tests in parallel.
+func ConfigureViperInstance(v *viper.Viper, envPrefix string, opts ...ViperOption) CommandOption {
+	return AfterAllHook(func(cmd *cobra.Command) {
+		ConfigureViperInstanceForCommand(cmd, v, envPrefix, opts...)
+	})
+}
+
 // ConfigureViperForCommand sets env prefix to 'prefix', automatic env to check in env
 // for any flags coming from anywhere (flag, config, default, etc.) as well as
 // scoping flags to the command it's defined in for global acces.
-func ConfigureViperForCommand(root *cobra.Command, envPrefix string) {
-	viper.SetEnvPrefix(strings.ToUpper(envPrefix))
-	viper.AutomaticEnv()
+//
+// The global viper singleton is used, see [ConfigureViperInstanceForCommand] to use
+// your own instance.
+func ConfigureViperForCommand(root *cobra.Command, envPrefix string, opts ...ViperOption) {
+	ConfigureViperInstanceForCommand(root, viper.GetViper(), envPrefix, opts...)
+}
+
+// ConfigureViperInstanceForCommand is exactly like [ConfigureViperForCommand] but uses
+// the received viper instance `v`.
+func ConfigureViperInstanceForCommand(root *cobra.Command, v *viper.Viper, envPrefix string, opts ...ViperOption) {
+	options := viperOptions{}
+	for _, opt := range opts {
+		opt.Apply(&options)
+	}
+
+	envPrefix = strings.ToUpper(envPrefix)
+
+	v.SetEnvPrefix(envPrefix)
+	v.AutomaticEnv()
+	v.SetEnvKeyReplacer(envKeyReplacer)
+
+	setCommandAnnotation(root, annotationViper, v)
+	setCommandAnnotation(root, annotationEnvPrefix, envPrefix)
+	recurseCommands(v, root, nil)
+
+	// Defined after the rebinding on purpose, it's not a configuration value
+	if options.printConfigFlag {
+		configurePrintConfigFlag(root)
+	}
+}
+
+// ViperFor returns the viper instance configured through [ConfigureViperInstance] for the
+// command's tree, the nearest configured ancestor winning. Returns the global viper
+// singleton if no instance was configured.
+//
+// When the config is reloaded by an [Application] (see [Application.WatchConfig]), read the
+// instance through [ReadViper] instead, viper is not safe for concurrent use.
+func ViperFor(cmd *cobra.Command) *viper.Viper {
+	for current := cmd; current != nil; current = current.Parent() {
+		if v, found := getCommandAnnotation(current, annotationViper); found {
+			return v.(*viper.Viper)
+		}
+	}
+
+	return viper.GetViper()
+}
 
-	// For backward compatibility, we support access through "_" and through "." for now,
-	// configuring the actual key delimiter use on the global viper instance is not
-	// possible.
-	replacer := strings.NewReplacer(".", "_", "-", "_")
-	viper.SetEnvKeyReplacer(replacer)
+// viperLocks holds the lock of each viper instance, guarding it against config reloads.
+var viperLocks sync.Map
 
-	recurseCommands(root, nil)
+func viperLock(v *viper.Viper) *sync.RWMutex {
+	lock, _ := viperLocks.LoadOrStore(v, &sync.RWMutex{})
+	return lock.(*sync.RWMutex)
 }
 
-func recurseCommands(root *cobra.Command, segments []string) {
+// ReadViper calls `read` with the viper instance of `cmd` (see [ViperFor]) while holding the
+// lock [Application.ReloadConfig] takes to swap a reloaded config in, the `sflags` getters
+// read through it. Writing to the instance within `read` is not allowed.
+func ReadViper(cmd *cobra.Command, read func(v *viper.Viper)) {
+	v := ViperFor(cmd)
+
+	lock := viperLock(v)
+	lock.RLock()
+	defer lock.RUnlock()
+
+	read(v)
+}
+
+// FlagEnvVar returns the environment variable name from which the value of `flag` can be
+// provided, this is only available for flags rebound by [ConfigureViper].
+func FlagEnvVar(cmd *cobra.Command, flag *pflag.Flag) (string, bool) {
+	key, found := reboundKey(flag)
+	if !found {
+		return "", false
+	}
+
+	return envVarName(cmd, key), true
+}
+
+// envVarName follows how viper computes the environment variable name of a key.
+func envVarName(cmd *cobra.Command, key string) string {
+	for current := cmd; current != nil; current = current.Parent() {
+		if prefix, found := getCommandAnnotation(current, annotationEnvPrefix); found && prefix != "" {
+			key = prefix.(string) + "_" + key
+			break
+		}
+	}
+
+	return envKeyReplacer.Replace(strings.ToUpper(key))
+}
+
+func recurseCommands(v *viper.Viper, root *cobra.Command, segments []string) {
 	if tracer.Enabled() {
 		zlog.Debug("re-binding flags", zap.String("cmd", root.Name()), zap.Strings("segments", segments))
 
@@ -38,28 +159,49 @@ func recurseCommands(root *cobra.Command, segments []string) {
 
 	persistentSegments := append(segments, "global")
 	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
-		rebindFlag("persistent", f, append(persistentSegments, f.Name))
+		rebindFlag(v, "persistent", f, append(persistentSegments, f.Name))
 	})
 
 	root.LocalNonPersistentFlags().VisitAll(func(f *pflag.Flag) {
-		rebindFlag("local", f, append(segments, f.Name))
+		rebindFlag(v, "local", f, append(segments, f.Name))
 	})
 
 	for _, cmd := range root.Commands() {
-		recurseCommands(cmd, append(segments, cmd.Name()))
+		if isInternalCommand(cmd) {
+			continue
+		}
+
+		recurseCommands(v, cmd, append(segments, cmd.Name()))
 	}
 }
 
-func rebindFlag(tag string, f *pflag.Flag, segments []string) {
+func rebindFlag(v *viper.Viper, tag string, f *pflag.Flag, segments []string) {
 	newVarDash := strings.Join(segments, "-")
 	newVarDot := strings.Join(segments, ".")
 
 	addAnnotation(f, ReboundFlagAnnotation, newVarDot)
 
-	viper.BindPFlag(newVarDash, f)
-	viper.BindPFlag(newVarDot, f)
+	v.BindPFlag(newVarDash, f)
+	v.BindPFlag(newVarDot, f)
 
-	zlog.Debug("binding "+tag+" flag", zap.String("actual", f.Name), zap.String("rebind_to", newVarDot+" (dash accepted)"))
+	zlog.Debug("binding "+tag+" flag",
+		zap.String("actual", f.Name),
+		zap.String("rebind_to", newVarDot+" (dash accepted)"),
+		zap.Any("default", redactedValue(f, f.Value.String())),
+	)
+}
+
+// dashKey returns the flat form of the rebound key `key`, both forms are bound by [ConfigureViper].
+func dashKey(key string) string {
+	return strings.ReplaceAll(key, ".", "-")
+}
+
+// setReboundValue overrides the value of the rebound key `key` in `v`, under both of its forms.
+func setReboundValue(v *viper.Viper, key string, value any) {
+	v.Set(key, value)
+	if flat := dashKey(key); flat != key {
+		v.Set(flat, value)
+	}
 }
 
 func addAnnotation(flag *pflag.Flag, key string, value string) {
@@ -69,3 +211,32 @@ func addAnnotation(flag *pflag.Flag, key string, value string) {
 
 	flag.Annotations[key] = append(flag.Annotations[key], value)
 }
+
+// reboundKey returns the viper key the flag was rebound to by [ConfigureViper], if any.
+func reboundKey(flag *pflag.Flag) (string, bool) {
+	if flag == nil || flag.Annotations == nil {
+		return "", false
+	}
+
+	values := flag.Annotations[ReboundFlagAnnotation]
+	if len(values) == 0 {
+		return "", false
+	}
+
+	return values[len(values)-1], true
+}
+
+// visitReboundFlags calls `onFlag` for every flag of the `root` command tree that was rebound
+// by [ConfigureViper], each flag being visited once on the command defining it.
+func visitReboundFlags(root *cobra.Command, onFlag func(cmd *cobra.Command, flag *pflag.Flag, key string)) {
+	visitAllCommands(root, func(cmd *cobra.Command) {
+		visit := func(flag *pflag.Flag) {
+			if key, found := reboundKey(flag); found {
+				onFlag(cmd, flag, key)
+			}
+		}
+
+		cmd.PersistentFlags().VisitAll(visit)
+		cmd.LocalNonPersistentFlags().VisitAll(visit)
+	})
+}
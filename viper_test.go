@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureViperInstance(t *testing.T) {
+	for _, prefix := range []string{"FIRST", "SECOND"} {
+		prefix := prefix
+
+		t.Run(prefix, func(t *testing.T) {
+			t.Parallel()
+
+			v := viper.New()
+
+			var value string
+			root := Root("acme", "CLI sample application",
+				Command(func(cmd *cobra.Command, args []string) error {
+					assert.Same(t, v, ViperFor(cmd))
+
+					value = ViperFor(cmd).GetString("read.name")
+					return nil
+				}, "read", "Read command",
+					Flags(func(flags *pflag.FlagSet) { flags.String("name", "", "Name") }),
+				),
+				ConfigureViperInstance(v, prefix),
+			)
+
+			root.SetArgs([]string{"read", "--name", prefix})
+			require.NoError(t, root.Execute())
+
+			assert.Equal(t, prefix, value)
+			assert.False(t, viper.IsSet("read.name"), "global viper instance should not have been touched")
+		})
+	}
+}